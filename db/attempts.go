@@ -2,11 +2,13 @@ package db
 
 import (
 	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
 type Attempt struct {
 	ChannelId    string    `gorm:"primary_key;column:channel_id"`
+	GuildId      string    `gorm:"column:guild_id"`
 	UserId       string    `gorm:"primary_key;column:user_id"`
 	Day          int       `gorm:"primary_key;column:day"`
 	MessageId    string    `gorm:"column:message_id"`
@@ -43,6 +45,96 @@ func (r *Repository) AttemptForMessage(channelId string, messageId string) (*Att
 	return &a, query.Error
 }
 
+// HasAttempt returns true if the user posted the wordle of the given day in the channel.
+func (r *Repository) HasAttempt(channelId string, userId string, day int) (bool, error) {
+	var count int64
+
+	query := r.Database().
+		Table("attempts").
+		Where("channel_id = ? and user_id = ? and day = ?", channelId, userId, day).
+		Count(&count)
+
+	return count > 0, query.Error
+}
+
+func (r *Repository) AttemptsForDay(channelId string, day int) ([]Attempt, error) {
+	var attempts []Attempt
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from
+			attempts a
+		where
+			a.channel_id = ? and a.day = ?
+		order by a.success desc, a.attempts, a.posted_at;`,
+			channelId, day).
+		Scan(&attempts)
+
+	return attempts, query.Error
+}
+
+// AttemptsBetweenDays returns the attempts of a channel from day from to day to, both inclusive.
+func (r *Repository) AttemptsBetweenDays(channelId string, from int, to int) ([]Attempt, error) {
+	var attempts []Attempt
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from
+			attempts a
+		where
+			a.channel_id = ? and a.day between ? and ?
+		order by a.day, a.posted_at;`,
+			channelId, from, to).
+		Scan(&attempts)
+
+	return attempts, query.Error
+}
+
+// GuildAttemptsBetweenDays returns the attempts of the tracked channels of a guild from day from to day to, both
+// inclusive. Users that posted the same day in more than one channel only have their first attempt of that day.
+func (r *Repository) GuildAttemptsBetweenDays(guildId string, from int, to int) ([]Attempt, error) {
+	var attempts []Attempt
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from (
+			select distinct on (a.user_id, a.day)
+				a.*
+			from
+				attempts a
+				join tracked_channels t on t.channel_id = a.channel_id
+			where
+				a.guild_id = ? and a.day between ? and ?
+			order by a.user_id, a.day, a.posted_at
+		) a
+		order by a.day, a.posted_at;`,
+			guildId, from, to).
+		Scan(&attempts)
+
+	return attempts, query.Error
+}
+
+// UserAttempts returns all the attempts of a user in a channel, in order of day.
+func (r *Repository) UserAttempts(channelId string, userId string) ([]Attempt, error) {
+	var attempts []Attempt
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from
+			attempts a
+		where
+			a.channel_id = ? and a.user_id = ?
+		order by a.day;`,
+			channelId, userId).
+		Scan(&attempts)
+
+	return attempts, query.Error
+}
+
 func (r *Repository) DeleteAttemptForMessage(channelId string, messageId string) (bool, error) {
 	query := r.Database().
 		Exec(`
@@ -59,3 +151,117 @@ func (r *Repository) DeleteAttemptForMessage(channelId string, messageId string)
 
 	return deleted, query.Error
 }
+
+// Streak returns the number of consecutive days, ending at the given day, in which the user solved the wordle
+// in the channel. Returns 0 if the user did not solve the wordle of the given day.
+func (r *Repository) Streak(channelId string, userId string, day int) (int, error) {
+	var streak int
+	query := r.Database().
+		Raw(`
+		with solved as (
+			select
+				day,
+				day + row_number() over (order by day desc) as run
+			from
+				attempts a
+			where
+				a.channel_id = ? and a.user_id = ? and a.success and a.day <= ?
+		)
+		select
+			count(*)
+		from
+			solved s
+		where
+			s.run = (select run from solved where day = ?);`,
+			channelId, userId, day, day).
+		Scan(&streak)
+
+	return streak, query.Error
+}
+
+// DayPlayers is a day with the number of players that posted its wordle in a channel.
+type DayPlayers struct {
+	Day     int `gorm:"column:day"`
+	Players int `gorm:"column:players"`
+}
+
+// RecentDays returns, most recent first, up to limit days of a channel with attempts, up to day to, whose number
+// starts with prefix.
+func (r *Repository) RecentDays(channelId string, to int, prefix string, limit int) ([]DayPlayers, error) {
+	var days []DayPlayers
+	query := r.Database().
+		Raw(`
+		select
+			a.day,
+			count(*) as "players"
+		from
+			attempts a
+		where
+			a.channel_id = ? and a.day <= ? and cast(a.day as varchar) like ?
+		group by a.day
+		order by a.day desc
+		limit ?;`,
+			channelId, to, escapeLike(prefix)+"%", limit).
+		Scan(&days)
+
+	return days, query.Error
+}
+
+// Player is someone who posted wordles in a channel.
+type Player struct {
+	UserId   string `gorm:"column:user_id"`
+	UserName string `gorm:"column:user_name"`
+	Played   int    `gorm:"column:played"`
+}
+
+// Players returns up to limit players of a channel whose name contains the given text, most recently active first.
+func (r *Repository) Players(channelId string, name string, limit int) ([]Player, error) {
+	var players []Player
+	query := r.Database().
+		Raw(`
+		select
+			a.user_id,
+			max(a.user_name) as "user_name",
+			count(*) as "played"
+		from
+			attempts a
+		where
+			a.channel_id = ?
+		group by a.user_id
+		having max(a.user_name) ilike ?
+		order by max(a.day) desc, 3 desc
+		limit ?;`,
+			channelId, "%"+escapeLike(name)+"%", limit).
+		Scan(&players)
+
+	return players, query.Error
+}
+
+// Player returns the player of a channel with the given user id, or nil if they never posted in it.
+func (r *Repository) Player(channelId string, userId string) (*Player, error) {
+	var players []Player
+	query := r.Database().
+		Raw(`
+		select
+			a.user_id,
+			max(a.user_name) as "user_name",
+			count(*) as "played"
+		from
+			attempts a
+		where
+			a.channel_id = ? and a.user_id = ?
+		group by a.user_id;`,
+			channelId, userId).
+		Scan(&players)
+
+	if query.Error != nil || len(players) == 0 {
+		return nil, query.Error
+	}
+
+	return &players[0], nil
+}
+
+// escapeLike escapes the wildcards of like patterns in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
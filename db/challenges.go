@@ -0,0 +1,110 @@
+package db
+
+import (
+	"time"
+)
+
+// Challenge is a word set by a member of a channel for others to guess.
+type Challenge struct {
+	Id          int64  `gorm:"primary_key;column:id"`
+	ChannelId   string `gorm:"column:channel_id"`
+	CreatorId   string `gorm:"column:creator_id"`
+	CreatorName string `gorm:"column:creator_name"`
+	// ChallengedId is the only user allowed to solve the challenge, or empty if anyone in the channel can.
+	ChallengedId string    `gorm:"column:challenged_id"`
+	Word         string    `gorm:"column:word"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+type ChallengeResult struct {
+	ChallengeId  int64     `gorm:"primary_key;column:challenge_id"`
+	UserId       string    `gorm:"primary_key;column:user_id"`
+	ChannelId    string    `gorm:"column:channel_id"`
+	UserName     string    `gorm:"column:user_name"`
+	Attempts     int       `gorm:"column:attempts"`
+	MaxAttempts  int       `gorm:"column:max_attempts"`
+	Success      bool      `gorm:"column:success"`
+	HardMode     bool      `gorm:"column:hard_mode"`
+	AttemptsJson string    `gorm:"column:attempts_json"`
+	FinishedAt   time.Time `gorm:"column:finished_at"`
+}
+
+func (r *Repository) CreateChallenge(channelId string, creatorId string, creatorName string, challengedId string,
+	word string) (*Challenge, error) {
+	c := Challenge{}
+	query := r.Database().
+		Raw(`
+		insert into challenges (channel_id, creator_id, creator_name, challenged_id, word)
+		values (?, ?, ?, ?, ?)
+		returning *;`,
+			channelId, creatorId, creatorName, challengedId, word).
+		Scan(&c)
+
+	return &c, query.Error
+}
+
+// Challenge returns the challenge with the given id in a channel, or nil if there is none.
+func (r *Repository) Challenge(channelId string, id int64) (*Challenge, error) {
+	var challenges []Challenge
+	query := r.Database().
+		Table("challenges").
+		Where("channel_id = ? and id = ?", channelId, id).
+		Find(&challenges)
+
+	if query.Error != nil || len(challenges) == 0 {
+		return nil, query.Error
+	}
+
+	return &challenges[0], nil
+}
+
+// HasChallengeResult returns true if the user already played the challenge.
+func (r *Repository) HasChallengeResult(challengeId int64, userId string) (bool, error) {
+	var count int64
+
+	query := r.Database().
+		Table("challenge_results").
+		Where("challenge_id = ? and user_id = ?", challengeId, userId).
+		Count(&count)
+
+	return count > 0, query.Error
+}
+
+func (r *Repository) SaveChallengeResult(result ChallengeResult) error {
+	return r.Database().
+		Table("challenge_results").
+		Omit("finished_at").
+		Create(&result).Error
+}
+
+type ChallengeLeaderboardEntry struct {
+	UserId      string  `gorm:"column:user_id"`
+	Username    string  `gorm:"column:user_name"`
+	Solved      int     `gorm:"column:solved"`
+	Played      int     `gorm:"column:played"`
+	AvgAttempts float64 `gorm:"column:avg_attempts"`
+}
+
+// ChallengeLeaderboard ranks the players of the challenges of a channel by the number of challenges solved, and
+// then by the average number of attempts of the solved ones.
+func (r *Repository) ChallengeLeaderboard(channelId string) ([]ChallengeLeaderboardEntry, error) {
+	var l []ChallengeLeaderboardEntry
+	query := r.Database().
+		Raw(`
+		select
+			cr.user_id,
+			max(cr.user_name) as "user_name",
+			count(*) filter (where cr.success) as "solved",
+			count(*) as "played",
+			coalesce(avg(cr.attempts) filter (where cr.success), 0) as "avg_attempts"
+		from
+			challenge_results cr
+		where
+			cr.channel_id = ?
+		group by cr.user_id
+		order by 3 desc, 5 asc
+		`, channelId).
+		Scan(&l)
+
+	return l, query.Error
+}
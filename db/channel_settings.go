@@ -0,0 +1,62 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// ChannelSetting is a setting of a tracked channel, stored as text. Settings a channel never changed aren't stored.
+type ChannelSetting struct {
+	ChannelId string    `gorm:"primary_key;column:channel_id"`
+	Name      string    `gorm:"primary_key;column:name"`
+	Value     string    `gorm:"column:value"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+// ChannelSettings returns the values of the settings stored for a channel, by name.
+func (r *Repository) ChannelSettings(channelId string) (map[string]string, error) {
+	var settings []ChannelSetting
+
+	query := r.Database().
+		Table("channel_settings").
+		Where("channel_id = ?", channelId).
+		Find(&settings)
+
+	if query.Error != nil {
+		return nil, fmt.Errorf("getting channel settings: %w", query.Error)
+	}
+
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.Name] = s.Value
+	}
+
+	return values, nil
+}
+
+// SetChannelSettings stores the given values of the settings of a channel, by name, leaving the others untouched.
+func (r *Repository) SetChannelSettings(channelId string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	settings := make([]ChannelSetting, 0, len(values))
+	for name, value := range values {
+		settings = append(settings, ChannelSetting{
+			ChannelId: channelId,
+			Name:      name,
+			Value:     value,
+			UpdatedAt: now,
+		})
+	}
+
+	return r.Database().
+		Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).
+		Table("channel_settings").
+		Create(&settings).Error
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"time"
+)
+
+const (
+	JobKindChannelScan = "channel_scan"
+
+	JobStatusQueued  = "queued"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusFailed  = "failed"
+)
+
+type Job struct {
+	Id             int64      `gorm:"primary_key;column:id"`
+	Kind           string     `gorm:"column:kind"`
+	ChannelId      string     `gorm:"column:channel_id"`
+	Status         string     `gorm:"column:status"`
+	TotalMessages  int        `gorm:"column:total_messages"`
+	WordleMessages int        `gorm:"column:wordle_messages"`
+	Error          string     `gorm:"column:error"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	StartedAt      *time.Time `gorm:"column:started_at"`
+	FinishedAt     *time.Time `gorm:"column:finished_at"`
+}
+
+// EnqueueJob creates a new queued job of the given kind for a channel. If the channel already has a queued or
+// running job of the same kind, that job is returned instead and created is false.
+func (r *Repository) EnqueueJob(kind string, channelId string) (job *Job, created bool, err error) {
+	for {
+		j := Job{}
+		query := r.Database().
+			Raw(`
+			insert into jobs (kind, channel_id, status)
+			values (?, ?, ?)
+			on conflict (kind, channel_id) where status in ('queued', 'running') do nothing
+			returning *;`,
+				kind, channelId, JobStatusQueued).
+			Scan(&j)
+		if query.Error != nil {
+			return nil, false, query.Error
+		}
+
+		if query.RowsAffected != 0 {
+			return &j, true, nil
+		}
+
+		active, err := r.ActiveJob(kind, channelId)
+		if err != nil || active != nil {
+			return active, false, err
+		}
+		// The active job finished between the insert and the lookup, so there's room for a new one.
+	}
+}
+
+// ActiveJob returns the queued or running job of the given kind for a channel, or nil if there is none.
+func (r *Repository) ActiveJob(kind string, channelId string) (*Job, error) {
+	var jobs []Job
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from
+			jobs j
+		where
+			j.kind = ? and j.channel_id = ? and j.status in ('queued', 'running');`,
+			kind, channelId).
+		Scan(&jobs)
+
+	if query.Error != nil || len(jobs) == 0 {
+		return nil, query.Error
+	}
+
+	return &jobs[0], nil
+}
+
+// ClaimNextJob marks the oldest queued job as running and returns it. Returns nil if there are no queued jobs.
+// Concurrent callers never claim the same job.
+func (r *Repository) ClaimNextJob() (*Job, error) {
+	var jobs []Job
+	query := r.Database().
+		Raw(`
+		update jobs
+		set
+			status = ?, started_at = now()
+		where id = (
+			select
+				id
+			from
+				jobs
+			where
+				status = ?
+			order by created_at
+			limit 1
+			for update skip locked
+		)
+		returning *;`,
+			JobStatusRunning, JobStatusQueued).
+		Scan(&jobs)
+
+	if query.Error != nil || len(jobs) == 0 {
+		return nil, query.Error
+	}
+
+	return &jobs[0], nil
+}
+
+// UpdateJobProgress saves the number of messages processed so far by a job.
+func (r *Repository) UpdateJobProgress(id int64, totalMessages int, wordleMessages int) error {
+	return r.Database().
+		Exec(`
+		update jobs
+		set
+			total_messages = ?, wordle_messages = ?
+		where
+			id = ?;`,
+			totalMessages, wordleMessages, id).Error
+}
+
+// FinishJob marks a job as done, or as failed if jobErr is not nil.
+func (r *Repository) FinishJob(id int64, jobErr error) error {
+	status, message := JobStatusDone, ""
+	if jobErr != nil {
+		status, message = JobStatusFailed, jobErr.Error()
+	}
+
+	return r.Database().
+		Exec(`
+		update jobs
+		set
+			status = ?, error = ?, finished_at = now()
+		where
+			id = ?;`,
+			status, message, id).Error
+}
+
+// RequeueRunningJobs puts jobs left running by a previous instance of the bot back in the queue.
+func (r *Repository) RequeueRunningJobs() (int64, error) {
+	query := r.Database().
+		Exec(`
+		update jobs
+		set
+			status = ?, started_at = null
+		where
+			status = ?;`,
+			JobStatusQueued, JobStatusRunning)
+
+	return query.RowsAffected, query.Error
+}
+
+// RecentJobs returns the most recent jobs for a channel, newest first.
+func (r *Repository) RecentJobs(channelId string, limit int) ([]Job, error) {
+	var jobs []Job
+	query := r.Database().
+		Raw(`
+		select
+			*
+		from
+			jobs j
+		where
+			j.channel_id = ?
+		order by j.created_at desc
+		limit ?;`,
+			channelId, limit).
+		Scan(&jobs)
+
+	return jobs, query.Error
+}
@@ -1,46 +1,95 @@
 package db
 
+// currentDaySQL is the SQL expression for the number of the wordle puzzle of the current day.
+const currentDaySQL = `DATE_PART('day', now() - '2021-06-19')`
+
 type UserScore struct {
 	UserName string  `gorm:"column:user_name"`
 	Score    float64 `gorm:"column:score"`
 }
 
+// LeaderboardEntry is the position of a player in a leaderboard. The bot ranks the players itself, with the scoring
+// settings of the channel.
 type LeaderboardEntry struct {
+	UserId      string  `gorm:"column:user_id"`
 	Username    string  `gorm:"column:user_name"`
 	TotalScore  float64 `gorm:"column:total_score"`
 	AvgAttempts float64 `gorm:"column:avg_attempts"`
 	Played      int     `gorm:"column:played"`
 }
 
-func (r *Repository) Leaderboard(channelId string) ([]LeaderboardEntry, error) {
-	var l []LeaderboardEntry
+type Regular struct {
+	UserId   string `gorm:"column:user_id"`
+	Username string `gorm:"column:user_name"`
+	Played   int    `gorm:"column:played"`
+}
+
+// MissingRegulars returns the users that posted in at least minDays of the last days days of the channel, not counting
+// today, but didn't post today's wordle yet. Days are numbered the same way as in the leaderboard.
+func (r *Repository) MissingRegulars(channelId string, minDays int, days int) ([]Regular, error) {
+	var regulars []Regular
 	query := r.Database().
 		Raw(`
 		select
-			a.user_name,
-			sum(a.new_score) as "total_score",
-			avg(a.attempts) as "avg_attempts",
+			a.user_id,
+			max(a.user_name) as "user_name",
 			count(*) as "played"
-		from (
-			select
-				user_name,
-				attempts,
-				(30 - ((select date_part as value from DATE_PART('day', now() - '2021-06-19')) - day) )/30 * (
-				case
-					when a.success then (7-attempts)*(7-attempts)+2
-					else 2
-				end) new_score
-			from
-				attempts a
-			where
-				channel_id = ? and 
-				day > (DATE_PART('day', now() - '2021-06-19') -30)
-			order by day desc
-		) a
-		group by a.user_name
-		order by 2 desc
-		`, channelId).
-		Scan(&l)
-
-	return l, query.Error
+		from
+			attempts a
+		where
+			a.channel_id = ? and
+			a.day >= `+currentDaySQL+` - ? and
+			a.day < `+currentDaySQL+`
+		group by a.user_id
+		having
+			count(*) >= ? and
+			not exists (
+				select
+					1
+				from
+					attempts t
+				where
+					t.channel_id = ? and t.user_id = a.user_id and t.day = `+currentDaySQL+`
+			)
+		order by 3 desc, 2
+		`, channelId, days, minDays, channelId).
+		Scan(&regulars)
+
+	return regulars, query.Error
+}
+
+// HeadToHeadDay has the results of two players on a day both of them played.
+type HeadToHeadDay struct {
+	Day       int  `gorm:"column:day"`
+	AttemptsA int  `gorm:"column:attempts_a"`
+	SuccessA  bool `gorm:"column:success_a"`
+	HardModeA bool `gorm:"column:hard_mode_a"`
+	AttemptsB int  `gorm:"column:attempts_b"`
+	SuccessB  bool `gorm:"column:success_b"`
+	HardModeB bool `gorm:"column:hard_mode_b"`
+}
+
+// HeadToHead returns the results of users a and b on the days both of them played in the channel, in order of day.
+func (r *Repository) HeadToHead(channelId string, userA string, userB string) ([]HeadToHeadDay, error) {
+	var days []HeadToHeadDay
+	query := r.Database().
+		Raw(`
+		select
+			a.day,
+			a.attempts as "attempts_a",
+			a.success as "success_a",
+			a.hard_mode as "hard_mode_a",
+			b.attempts as "attempts_b",
+			b.success as "success_b",
+			b.hard_mode as "hard_mode_b"
+		from
+			attempts a
+			join attempts b on b.channel_id = a.channel_id and b.day = a.day
+		where
+			a.channel_id = ? and a.user_id = ? and b.user_id = ?
+		order by a.day;`,
+			channelId, userA, userB).
+		Scan(&days)
+
+	return days, query.Error
 }
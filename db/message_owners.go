@@ -0,0 +1,45 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// MessageOwner is the player a message the bot posted on their behalf belongs to, like the result of a game played
+// in discord or a reposted spoiler.
+type MessageOwner struct {
+	ChannelId string `gorm:"primary_key;column:channel_id"`
+	MessageId string `gorm:"primary_key;column:message_id"`
+	UserId    string `gorm:"column:user_id"`
+	UserName  string `gorm:"column:user_name"`
+}
+
+func (r *Repository) SaveMessageOwner(owner MessageOwner) error {
+	return r.Database().
+		Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).
+		Table("message_owners").
+		Create(&owner).Error
+}
+
+// MessageOwner returns the owner of a message the bot posted, or nil if it wasn't posted on behalf of a player.
+func (r *Repository) MessageOwner(channelId string, messageId string) (*MessageOwner, error) {
+	var owners []MessageOwner
+
+	query := r.Database().
+		Table("message_owners").
+		Where("channel_id = ? and message_id = ?", channelId, messageId).
+		Find(&owners)
+
+	if query.Error != nil {
+		return nil, fmt.Errorf("getting message owner: %w", query.Error)
+	}
+
+	if len(owners) == 0 {
+		return nil, nil
+	}
+
+	return &owners[0], nil
+}
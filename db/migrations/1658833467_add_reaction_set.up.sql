@@ -0,0 +1,7 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS reaction_set varchar NOT NULL DEFAULT 'classic';
+
+COMMIT;
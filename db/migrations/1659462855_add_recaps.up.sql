@@ -0,0 +1,10 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS weekly_recap bool NOT NULL DEFAULT false;
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS monthly_recap bool NOT NULL DEFAULT false;
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS last_weekly_recap_day int4 NOT NULL DEFAULT 0;
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS last_monthly_recap_day int4 NOT NULL DEFAULT 0;
+
+COMMIT;
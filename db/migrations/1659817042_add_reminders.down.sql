@@ -0,0 +1,7 @@
+BEGIN;
+
+-- REMINDERS TABLE
+
+drop table if exists reminders;
+
+COMMIT;
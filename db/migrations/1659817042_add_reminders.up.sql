@@ -0,0 +1,15 @@
+BEGIN;
+
+-- REMINDERS TABLE
+
+CREATE TABLE IF NOT EXISTS reminders (
+     channel_id varchar NOT NULL,
+     user_id varchar NOT NULL,
+     remind_at varchar NOT NULL,
+     timezone varchar NOT NULL DEFAULT 'UTC',
+     delivery varchar NOT NULL DEFAULT 'dm',
+     last_reminded_day int4 NOT NULL DEFAULT 0,
+     CONSTRAINT reminders_pk PRIMARY KEY (channel_id, user_id)
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS spoiler_guard varchar NOT NULL DEFAULT 'off';
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- CHALLENGE RESULTS TABLE
+
+drop table if exists challenge_results;
+
+-- CHALLENGES TABLE
+
+drop table if exists challenges;
+
+COMMIT;
@@ -0,0 +1,37 @@
+BEGIN;
+
+-- CHALLENGES TABLE
+
+CREATE TABLE IF NOT EXISTS challenges (
+     id bigserial NOT NULL,
+     channel_id varchar NOT NULL,
+     creator_id varchar NOT NULL,
+     creator_name varchar NOT NULL,
+     challenged_id varchar NOT NULL DEFAULT '',
+     word varchar NOT NULL,
+     created_at timestamptz NOT NULL DEFAULT now(),
+     CONSTRAINT challenges_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS challenges_channel_id_idx ON challenges USING btree (channel_id);
+
+-- CHALLENGE RESULTS TABLE
+
+CREATE TABLE IF NOT EXISTS challenge_results (
+     challenge_id int8 NOT NULL,
+     channel_id varchar NOT NULL,
+     user_id varchar NOT NULL,
+     user_name varchar NOT NULL,
+     attempts int4 NOT NULL,
+     max_attempts int4 NOT NULL,
+     success bool NOT NULL,
+     hard_mode bool NOT NULL DEFAULT false,
+     attempts_json varchar NOT NULL,
+     finished_at timestamptz NOT NULL DEFAULT now(),
+     CONSTRAINT challenge_results_pk PRIMARY KEY (challenge_id, user_id),
+     CONSTRAINT challenge_results_challenge_fk FOREIGN KEY (challenge_id) REFERENCES challenges (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS challenge_results_channel_id_idx ON challenge_results USING btree (channel_id);
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- PRACTICE RESULTS TABLE
+
+CREATE TABLE IF NOT EXISTS practice_results (
+     id bigserial NOT NULL,
+     user_id varchar NOT NULL,
+     user_name varchar NOT NULL,
+     word varchar NOT NULL,
+     attempts int4 NOT NULL,
+     max_attempts int4 NOT NULL,
+     success bool NOT NULL,
+     hard_mode bool NOT NULL DEFAULT false,
+     attempts_json varchar NOT NULL,
+     finished_at timestamptz NOT NULL DEFAULT now(),
+     CONSTRAINT practice_results_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS practice_results_user_id_idx ON practice_results USING btree (user_id);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS public_responses boolean NOT NULL DEFAULT true;
+
+COMMIT;
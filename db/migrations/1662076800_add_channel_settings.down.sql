@@ -0,0 +1,38 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS reaction_set varchar NOT NULL DEFAULT 'classic';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS timezone varchar NOT NULL DEFAULT 'UTC';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS summary_time varchar NOT NULL DEFAULT '';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS weekly_recap bool NOT NULL DEFAULT false;
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS monthly_recap bool NOT NULL DEFAULT false;
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS spoiler_guard varchar NOT NULL DEFAULT 'off';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS public_responses boolean NOT NULL DEFAULT true;
+
+UPDATE tracked_channels t SET reaction_set = s.value
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'reactions';
+
+UPDATE tracked_channels t SET timezone = s.value
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'timezone';
+
+UPDATE tracked_channels t SET summary_time = s.value
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'summary-time' AND s.value <> 'off';
+
+UPDATE tracked_channels t SET weekly_recap = s.value = 'true'
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'weekly-recap';
+
+UPDATE tracked_channels t SET monthly_recap = s.value = 'true'
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'monthly-recap';
+
+UPDATE tracked_channels t SET spoiler_guard = s.value
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'spoiler-guard';
+
+UPDATE tracked_channels t SET public_responses = s.value = 'true'
+FROM channel_settings s WHERE s.channel_id = t.channel_id AND s.name = 'public-responses';
+
+-- CHANNEL SETTINGS TABLE
+
+DROP TABLE IF EXISTS channel_settings;
+
+COMMIT;
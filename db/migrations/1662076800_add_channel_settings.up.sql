@@ -0,0 +1,53 @@
+BEGIN;
+
+-- CHANNEL SETTINGS TABLE
+
+CREATE TABLE IF NOT EXISTS channel_settings (
+     channel_id varchar NOT NULL,
+     "name" varchar NOT NULL,
+     value varchar NOT NULL,
+     updated_at timestamptz NOT NULL DEFAULT now(),
+     CONSTRAINT channel_settings_pk PRIMARY KEY (channel_id, name)
+);
+
+-- Only the settings that differ from their defaults are moved, channels keep the defaults for the others.
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'reactions', reaction_set FROM tracked_channels WHERE reaction_set <> 'classic'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'timezone', timezone FROM tracked_channels WHERE timezone <> 'UTC'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'summary-time', summary_time FROM tracked_channels WHERE summary_time <> ''
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'weekly-recap', 'true' FROM tracked_channels WHERE weekly_recap
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'monthly-recap', 'true' FROM tracked_channels WHERE monthly_recap
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'spoiler-guard', spoiler_guard FROM tracked_channels WHERE spoiler_guard <> 'off'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO channel_settings (channel_id, name, value)
+SELECT channel_id, 'public-responses', 'false' FROM tracked_channels WHERE NOT public_responses
+ON CONFLICT DO NOTHING;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS reaction_set;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS timezone;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS summary_time;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS weekly_recap;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS monthly_recap;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS spoiler_guard;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS public_responses;
+
+COMMIT;
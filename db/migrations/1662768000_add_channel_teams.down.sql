@@ -0,0 +1,7 @@
+BEGIN;
+
+-- CHANNEL TEAMS TABLE
+
+DROP TABLE IF EXISTS channel_teams;
+
+COMMIT;
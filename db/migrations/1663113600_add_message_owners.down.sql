@@ -0,0 +1,7 @@
+BEGIN;
+
+-- MESSAGE OWNERS TABLE
+
+DROP TABLE IF EXISTS message_owners;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- JOBS TABLE
+
+drop table if exists jobs;
+
+COMMIT;
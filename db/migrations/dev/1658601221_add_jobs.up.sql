@@ -0,0 +1,26 @@
+BEGIN;
+
+-- JOBS TABLE
+
+CREATE TABLE IF NOT EXISTS jobs (
+     id bigserial NOT NULL,
+     kind varchar NOT NULL,
+     channel_id varchar NOT NULL,
+     status varchar NOT NULL,
+     total_messages int4 NOT NULL DEFAULT 0,
+     wordle_messages int4 NOT NULL DEFAULT 0,
+     error varchar NOT NULL DEFAULT '',
+     created_at timestamptz NOT NULL DEFAULT now(),
+     started_at timestamptz NULL,
+     finished_at timestamptz NULL,
+     CONSTRAINT jobs_pk PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS jobs_channel_id_idx ON jobs USING btree (channel_id);
+CREATE INDEX IF NOT EXISTS jobs_status_idx ON jobs USING btree (status);
+
+-- Only one queued or running job of each kind is allowed per channel
+CREATE UNIQUE INDEX IF NOT EXISTS jobs_active_channel_uniq_idx ON jobs (kind, channel_id)
+    WHERE status IN ('queued', 'running');
+
+COMMIT;
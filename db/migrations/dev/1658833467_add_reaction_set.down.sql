@@ -0,0 +1,7 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS reaction_set;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS last_summary_day;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS summary_time;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS timezone;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS timezone varchar NOT NULL DEFAULT 'UTC';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS summary_time varchar NOT NULL DEFAULT '';
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS last_summary_day int4 NOT NULL DEFAULT 0;
+
+COMMIT;
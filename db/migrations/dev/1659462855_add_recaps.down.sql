@@ -0,0 +1,10 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS last_monthly_recap_day;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS last_weekly_recap_day;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS monthly_recap;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS weekly_recap;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- PRACTICE RESULTS TABLE
+
+drop table if exists practice_results;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- ATTEMPTS TABLE
+
+DROP INDEX IF EXISTS attempts_guild_id_idx;
+ALTER TABLE attempts DROP COLUMN IF EXISTS guild_id;
+
+-- TRACKED CHANNELS TABLE
+
+DROP INDEX IF EXISTS tracked_channels_guild_id_idx;
+ALTER TABLE tracked_channels DROP COLUMN IF EXISTS guild_id;
+
+COMMIT;
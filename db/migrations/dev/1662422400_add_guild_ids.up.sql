@@ -0,0 +1,16 @@
+BEGIN;
+
+-- TRACKED CHANNELS TABLE
+
+-- Empty until the bot fills in the guild of channels tracked before guild ids were stored.
+ALTER TABLE tracked_channels ADD COLUMN IF NOT EXISTS guild_id varchar NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tracked_channels_guild_id_idx ON tracked_channels USING btree (guild_id);
+
+-- ATTEMPTS TABLE
+
+ALTER TABLE attempts ADD COLUMN IF NOT EXISTS guild_id varchar NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS attempts_guild_id_idx ON attempts USING btree (guild_id);
+
+COMMIT;
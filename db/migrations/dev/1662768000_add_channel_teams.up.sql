@@ -0,0 +1,13 @@
+BEGIN;
+
+-- CHANNEL TEAMS TABLE
+
+CREATE TABLE IF NOT EXISTS channel_teams (
+     channel_id varchar NOT NULL,
+     role_id varchar NOT NULL,
+     role_name varchar NOT NULL,
+     created_at timestamptz NOT NULL DEFAULT now(),
+     CONSTRAINT channel_teams_pk PRIMARY KEY (channel_id, role_id)
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- MESSAGE OWNERS TABLE
+
+CREATE TABLE IF NOT EXISTS message_owners (
+     channel_id varchar NOT NULL,
+     message_id varchar NOT NULL,
+     user_id varchar NOT NULL,
+     user_name varchar NOT NULL,
+     CONSTRAINT message_owners_pk PRIMARY KEY (channel_id, message_id)
+);
+
+COMMIT;
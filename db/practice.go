@@ -0,0 +1,39 @@
+package db
+
+import (
+	"time"
+)
+
+// PracticeResult is the result of a practice game. Practice games are played with random past answers and are kept
+// apart from the attempts of the daily puzzles.
+type PracticeResult struct {
+	Id           int64     `gorm:"primary_key;column:id"`
+	UserId       string    `gorm:"column:user_id"`
+	UserName     string    `gorm:"column:user_name"`
+	Word         string    `gorm:"column:word"`
+	Attempts     int       `gorm:"column:attempts"`
+	MaxAttempts  int       `gorm:"column:max_attempts"`
+	Success      bool      `gorm:"column:success"`
+	HardMode     bool      `gorm:"column:hard_mode"`
+	AttemptsJson string    `gorm:"column:attempts_json"`
+	FinishedAt   time.Time `gorm:"column:finished_at"`
+}
+
+func (r *Repository) SavePracticeResult(result PracticeResult) error {
+	return r.Database().
+		Table("practice_results").
+		Omit("id", "finished_at").
+		Create(&result).Error
+}
+
+// PracticeResults returns the practice results of a user, most recent first.
+func (r *Repository) PracticeResults(userId string) ([]PracticeResult, error) {
+	var results []PracticeResult
+	query := r.Database().
+		Table("practice_results").
+		Where("user_id = ?", userId).
+		Order("finished_at desc, id desc").
+		Find(&results)
+
+	return results, query.Error
+}
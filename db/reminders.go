@@ -0,0 +1,61 @@
+package db
+
+import (
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ReminderDeliveryDM      = "dm"
+	ReminderDeliveryMention = "mention"
+)
+
+type Reminder struct {
+	ChannelId string `gorm:"primary_key;column:channel_id"`
+	UserId    string `gorm:"primary_key;column:user_id"`
+	// RemindAt is the local time, in HH:MM format, after which the user is reminded.
+	RemindAt string `gorm:"column:remind_at"`
+	Timezone string `gorm:"column:timezone"`
+	// Delivery is how the user is reminded, either by DM or by a mention in the channel.
+	Delivery        string `gorm:"column:delivery"`
+	LastRemindedDay int    `gorm:"column:last_reminded_day"`
+}
+
+// SaveReminder creates or replaces the reminder of a user in a channel. Replacing a reminder keeps the last day it
+// was sent, so the user isn't reminded twice on the same day.
+func (r *Repository) SaveReminder(reminder Reminder) error {
+	return r.Database().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "channel_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"remind_at", "timezone", "delivery"}),
+		}).
+		Table("reminders").
+		Create(&reminder).Error
+}
+
+func (r *Repository) DeleteReminder(channelId string, userId string) (bool, error) {
+	query := r.Database().
+		Exec(`
+		delete from
+			reminders r
+		where
+			r.channel_id = ? and r.user_id = ?;`,
+			channelId, userId)
+
+	return query.RowsAffected != 0, query.Error
+}
+
+func (r *Repository) Reminders() ([]Reminder, error) {
+	var reminders []Reminder
+	query := r.Database().
+		Table("reminders").
+		Find(&reminders)
+
+	return reminders, query.Error
+}
+
+func (r *Repository) SetLastRemindedDay(channelId string, userId string, day int) error {
+	return r.Database().
+		Table("reminders").
+		Where("channel_id = ? and user_id = ?", channelId, userId).
+		Update("last_reminded_day", day).Error
+}
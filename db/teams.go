@@ -0,0 +1,56 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm/clause"
+)
+
+// ChannelTeam is a team competing in a channel, made of the members with a role.
+type ChannelTeam struct {
+	ChannelId string `gorm:"primary_key;column:channel_id"`
+	RoleId    string `gorm:"primary_key;column:role_id"`
+	// RoleName is the name of the role when the team was added, for roles the bot can no longer see.
+	RoleName string `gorm:"column:role_name"`
+}
+
+// ChannelTeams returns the teams of a channel, in the order they were added.
+func (r *Repository) ChannelTeams(channelId string) ([]ChannelTeam, error) {
+	var teams []ChannelTeam
+
+	query := r.Database().
+		Table("channel_teams").
+		Where("channel_id = ?", channelId).
+		Order("created_at").
+		Find(&teams)
+
+	if query.Error != nil {
+		return nil, fmt.Errorf("getting channel teams: %w", query.Error)
+	}
+
+	return teams, nil
+}
+
+// SaveChannelTeam adds a team to a channel, or updates the role name of a team it already has.
+func (r *Repository) SaveChannelTeam(team ChannelTeam) error {
+	return r.Database().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "channel_id"}, {Name: "role_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role_name"}),
+		}).
+		Table("channel_teams").
+		Create(&team).Error
+}
+
+// DeleteChannelTeam removes a team from a channel. Returns false if the channel didn't have that team.
+func (r *Repository) DeleteChannelTeam(channelId string, roleId string) (bool, error) {
+	query := r.Database().
+		Exec(`
+		delete from
+			channel_teams t
+		where
+			t.channel_id = ? and t.role_id = ?;`,
+			channelId, roleId)
+
+	return query.RowsAffected != 0, query.Error
+}
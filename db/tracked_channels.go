@@ -2,11 +2,19 @@ package db
 
 import (
 	"fmt"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// TrackedChannel is a channel whose wordles are recorded. Its settings are kept apart, in channel_settings.
 type TrackedChannel struct {
 	ChannelId string `gorm:"primary_key;column:channel_id"`
+	// GuildId is the server of the channel. Empty for channels tracked before it was stored, until it's filled in.
+	GuildId        string `gorm:"column:guild_id"`
+	LastSummaryDay int    `gorm:"column:last_summary_day"`
+	// LastWeeklyRecapDay and LastMonthlyRecapDay are the last days covered by the latest recaps posted.
+	LastWeeklyRecapDay  int `gorm:"column:last_weekly_recap_day"`
+	LastMonthlyRecapDay int `gorm:"column:last_monthly_recap_day"`
 }
 
 func (r *Repository) IsTrackedChannel(channelId string) (bool, error) {
@@ -24,7 +32,7 @@ func (r *Repository) IsTrackedChannel(channelId string) (bool, error) {
 	return count > 0, nil
 }
 
-func (r *Repository) TrackChannel(channelId string) error {
+func (r *Repository) TrackChannel(channelId string, guildId string) error {
 	query := r.Database().
 		Clauses(clause.OnConflict{
 			OnConstraint: "tracked_channels_pk",
@@ -33,7 +41,84 @@ func (r *Repository) TrackChannel(channelId string) error {
 		Table("tracked_channels").
 		Create(&TrackedChannel{
 			ChannelId: channelId,
+			GuildId:   guildId,
 		})
 
 	return query.Error
 }
+
+func (r *Repository) TrackedChannel(channelId string) (*TrackedChannel, error) {
+	var channels []TrackedChannel
+
+	query := r.Database().
+		Table("tracked_channels").
+		Where("channel_id = ?", channelId).
+		Find(&channels)
+
+	if query.Error != nil {
+		return nil, fmt.Errorf("getting tracked channel: %w", query.Error)
+	}
+
+	if len(channels) == 0 {
+		return nil, nil
+	}
+
+	return &channels[0], nil
+}
+
+func (r *Repository) TrackedChannels() ([]TrackedChannel, error) {
+	var channels []TrackedChannel
+
+	query := r.Database().
+		Table("tracked_channels").
+		Find(&channels)
+
+	if query.Error != nil {
+		return nil, fmt.Errorf("getting tracked channels: %w", query.Error)
+	}
+
+	return channels, nil
+}
+
+func (r *Repository) SetLastSummaryDay(channelId string, day int) error {
+	return r.Database().
+		Table("tracked_channels").
+		Where("channel_id = ?", channelId).
+		Update("last_summary_day", day).Error
+}
+
+func (r *Repository) SetLastWeeklyRecapDay(channelId string, day int) error {
+	return r.Database().
+		Table("tracked_channels").
+		Where("channel_id = ?", channelId).
+		Update("last_weekly_recap_day", day).Error
+}
+
+func (r *Repository) SetLastMonthlyRecapDay(channelId string, day int) error {
+	return r.Database().
+		Table("tracked_channels").
+		Where("channel_id = ?", channelId).
+		Update("last_monthly_recap_day", day).Error
+}
+
+// SetChannelGuild stores the guild of a channel tracked before guild ids were stored, and of the attempts recorded
+// in it.
+func (r *Repository) SetChannelGuild(channelId string, guildId string) error {
+	return r.Database().Transaction(func(tx *gorm.DB) error {
+		err := tx.Table("tracked_channels").
+			Where("channel_id = ?", channelId).
+			Update("guild_id", guildId).Error
+		if err != nil {
+			return fmt.Errorf("setting guild of tracked channel: %w", err)
+		}
+
+		err = tx.Table("attempts").
+			Where("channel_id = ? and guild_id = ''", channelId).
+			Update("guild_id", guildId).Error
+		if err != nil {
+			return fmt.Errorf("setting guild of attempts: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,287 @@
+// Package game implements the rules of wordle: evaluating guesses against a target word, hard mode and the emoji
+// grid used to share results.
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// WordLength is the number of letters of target words and guesses.
+const WordLength = 5
+
+// DefaultMaxGuesses is the number of guesses a player has to find the target word.
+const DefaultMaxGuesses = 6
+
+type LetterResult int
+
+const (
+	// Absent letters are not in the target word, or all their occurrences are already accounted for.
+	Absent LetterResult = iota
+	// Present letters are in the target word, but in a different position.
+	Present
+	// Correct letters are in the target word in the same position.
+	Correct
+)
+
+// Emoji returns the square used for the result in shared grids.
+func (r LetterResult) Emoji() string {
+	switch r {
+	case Correct:
+		return "🟩"
+	case Present:
+		return "🟨"
+	default:
+		return "⬛"
+	}
+}
+
+var (
+	ErrGameOver      = errors.New("the game is already over")
+	ErrWrongLength   = fmt.Errorf("guesses must have %d letters", WordLength)
+	ErrNotInWordList = errors.New("not in word list")
+)
+
+// HardModeError is returned for guesses that don't use the hints revealed by previous guesses in hard mode.
+type HardModeError struct {
+	Letter rune
+	// Position is the 0-based position the letter must be in, or -1 if it must only be somewhere in the guess.
+	Position int
+}
+
+func (e *HardModeError) Error() string {
+	if e.Position < 0 {
+		return fmt.Sprintf("guess must contain %c", e.Letter)
+	}
+	return fmt.Sprintf("%s letter must be %c", ordinal(e.Position+1), e.Letter)
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
+
+// Evaluate returns the result of each letter of a guess against the target word. Both words must be uppercase (or
+// both lowercase) and have the same length. Repeated letters are only marked as present as many times as they
+// appear in the target word and not already marked as correct, leftmost first.
+func Evaluate(guess string, target string) []LetterResult {
+	g, t := []rune(guess), []rune(target)
+	results := make([]LetterResult, len(g))
+
+	unmatched := make(map[rune]int)
+	for i := range g {
+		if i < len(t) && g[i] == t[i] {
+			results[i] = Correct
+		} else if i < len(t) {
+			unmatched[t[i]]++
+		}
+	}
+
+	for i := range g {
+		if results[i] == Correct {
+			continue
+		}
+		if unmatched[g[i]] > 0 {
+			results[i] = Present
+			unmatched[g[i]]--
+		}
+	}
+
+	return results
+}
+
+// Options changes how a game is played.
+type Options struct {
+	// HardMode requires every guess to use the hints revealed by the previous guesses.
+	HardMode bool
+	// MaxGuesses is the number of guesses allowed. Defaults to DefaultMaxGuesses.
+	MaxGuesses int
+	// IsValidWord reports if a word can be used as a guess. If nil, any word with the right length is accepted.
+	IsValidWord func(word string) bool
+}
+
+// Game is a single game of wordle.
+type Game struct {
+	target  string
+	options Options
+	guesses []string
+	results [][]LetterResult
+}
+
+// New starts a game with the given target word.
+func New(target string, options Options) *Game {
+	if options.MaxGuesses <= 0 {
+		options.MaxGuesses = DefaultMaxGuesses
+	}
+
+	return &Game{
+		target:  strings.ToUpper(target),
+		options: options,
+	}
+}
+
+// Guess plays a word. Invalid guesses return an error and don't count as a guess.
+func (g *Game) Guess(word string) ([]LetterResult, error) {
+	if g.Over() {
+		return nil, ErrGameOver
+	}
+
+	word = strings.ToUpper(strings.TrimSpace(word))
+	if utf8.RuneCountInString(word) != WordLength {
+		return nil, ErrWrongLength
+	}
+
+	if g.options.IsValidWord != nil && !g.options.IsValidWord(word) {
+		return nil, ErrNotInWordList
+	}
+
+	if g.options.HardMode {
+		if err := g.checkHardMode(word); err != nil {
+			return nil, err
+		}
+	}
+
+	result := Evaluate(word, g.target)
+	g.guesses = append(g.guesses, word)
+	g.results = append(g.results, result)
+
+	return result, nil
+}
+
+// checkHardMode checks that a guess keeps the correct letters in place and uses every present letter revealed by
+// previous guesses.
+func (g *Game) checkHardMode(word string) error {
+	w := []rune(word)
+
+	for n, guess := range g.guesses {
+		prev := []rune(guess)
+		result := g.results[n]
+
+		for i, r := range result {
+			if r == Correct && w[i] != prev[i] {
+				return &HardModeError{Letter: prev[i], Position: i}
+			}
+		}
+
+		required := make(map[rune]int)
+		for i, r := range result {
+			if r == Present || r == Correct {
+				required[prev[i]]++
+			}
+		}
+		for i, r := range result {
+			if r != Present {
+				continue
+			}
+			if strings.Count(word, string(prev[i])) < required[prev[i]] {
+				return &HardModeError{Letter: prev[i], Position: -1}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Target returns the word to guess, in uppercase.
+func (g *Game) Target() string {
+	return g.target
+}
+
+// HardMode returns true if the game is played in hard mode.
+func (g *Game) HardMode() bool {
+	return g.options.HardMode
+}
+
+// MaxGuesses returns the number of guesses allowed.
+func (g *Game) MaxGuesses() int {
+	return g.options.MaxGuesses
+}
+
+// Guesses returns the words guessed so far, in uppercase.
+func (g *Game) Guesses() []string {
+	return append([]string(nil), g.guesses...)
+}
+
+// Won returns true if the target word was guessed.
+func (g *Game) Won() bool {
+	if len(g.results) == 0 {
+		return false
+	}
+
+	for _, r := range g.results[len(g.results)-1] {
+		if r != Correct {
+			return false
+		}
+	}
+	return true
+}
+
+// Over returns true if the target word was guessed or there are no guesses left.
+func (g *Game) Over() bool {
+	return g.Won() || len(g.guesses) >= g.options.MaxGuesses
+}
+
+// AbsentLetters returns the letters guessed so far that are not in the target word, in the order they were guessed.
+func (g *Game) AbsentLetters() []rune {
+	var absent []rune
+	for _, guess := range g.guesses {
+		for _, l := range guess {
+			if !strings.ContainsRune(g.target, l) && !containsRune(absent, l) {
+				absent = append(absent, l)
+			}
+		}
+	}
+	return absent
+}
+
+func containsRune(runes []rune, r rune) bool {
+	for _, x := range runes {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Grid returns the emoji squares of the guesses so far, one guess per line.
+func (g *Game) Grid() string {
+	lines := make([]string, len(g.results))
+	for i, result := range g.results {
+		var builder strings.Builder
+		for _, r := range result {
+			builder.WriteString(r.Emoji())
+		}
+		lines[i] = builder.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Score returns the score as shown in shared results, e.g. "3/6" or "X/6", with a trailing * in hard mode.
+func (g *Game) Score() string {
+	guesses := "X"
+	if g.Won() {
+		guesses = fmt.Sprint(len(g.guesses))
+	}
+
+	score := fmt.Sprintf("%s/%d", guesses, g.options.MaxGuesses)
+	if g.options.HardMode {
+		score += "*"
+	}
+	return score
+}
+
+// Share returns the result in the same format as the official game share text, titled with the given name
+// (e.g. "Wordle 217").
+func (g *Game) Share(title string) string {
+	return fmt.Sprintf("%s %s\n\n%s", title, g.Score(), g.Grid())
+}
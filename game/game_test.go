@@ -0,0 +1,358 @@
+package game_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/game"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+const (
+	A = game.Absent
+	P = game.Present
+	C = game.Correct
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		guess  string
+		target string
+		want   []game.LetterResult
+	}{
+		{
+			name:   "all correct",
+			guess:  "CIGAR",
+			target: "CIGAR",
+			want:   []game.LetterResult{C, C, C, C, C},
+		},
+		{
+			name:   "all absent",
+			guess:  "BLUNT",
+			target: "CIGAR",
+			want:   []game.LetterResult{A, A, A, A, A},
+		},
+		{
+			name:   "present and correct",
+			guess:  "CRANE",
+			target: "CIGAR",
+			want:   []game.LetterResult{C, P, P, A, A},
+		},
+		{
+			name:   "repeated letter in guess only once in target",
+			guess:  "SPEED",
+			target: "ABIDE",
+			want:   []game.LetterResult{A, A, P, A, P},
+		},
+		{
+			name:   "repeated letter with one correct",
+			guess:  "EERIE",
+			target: "THOSE",
+			want:   []game.LetterResult{A, A, A, A, C},
+		},
+		{
+			name:   "repeated letter in guess and target",
+			guess:  "ALLEY",
+			target: "LLAMA",
+			want:   []game.LetterResult{P, C, P, A, A},
+		},
+		{
+			name:   "repeated letter in target only",
+			guess:  "MOIST",
+			target: "MAMMA",
+			want:   []game.LetterResult{C, A, A, A, A},
+		},
+		{
+			name:   "correct letter takes precedence over earlier present one",
+			guess:  "LEVEL",
+			target: "HELLO",
+			want:   []game.LetterResult{P, C, A, A, P},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := game.Evaluate(tt.guess, tt.target)
+			if !resultsEqual(got, tt.want) {
+				t.Fatalf("Evaluate(%q, %q) = %v, want %v", tt.guess, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuess(t *testing.T) {
+	tests := []struct {
+		name    string
+		options game.Options
+		guesses []string
+		// wantErr is the error expected for the last guess, nil if all guesses are valid.
+		wantErr error
+	}{
+		{
+			name:    "valid guess",
+			guesses: []string{"crane"},
+		},
+		{
+			name:    "guess too short",
+			guesses: []string{"cran"},
+			wantErr: game.ErrWrongLength,
+		},
+		{
+			name:    "guess too long",
+			guesses: []string{"cranes"},
+			wantErr: game.ErrWrongLength,
+		},
+		{
+			name:    "guess not in word list",
+			options: game.Options{IsValidWord: wordle.IsValidGuess},
+			guesses: []string{"zzzzz"},
+			wantErr: game.ErrNotInWordList,
+		},
+		{
+			name:    "guess after winning",
+			guesses: []string{"cigar", "crane"},
+			wantErr: game.ErrGameOver,
+		},
+		{
+			name:    "guess after running out of guesses",
+			options: game.Options{MaxGuesses: 2},
+			guesses: []string{"crane", "blunt", "moist"},
+			wantErr: game.ErrGameOver,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game.New("cigar", tt.options)
+
+			var err error
+			for _, guess := range tt.guesses {
+				_, err = g.Guess(guess)
+				if err != nil {
+					break
+				}
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Guess() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInvalidGuessDoesNotCount(t *testing.T) {
+	g := game.New("cigar", game.Options{IsValidWord: wordle.IsValidGuess})
+
+	_, err := g.Guess("zzzzz")
+	if err == nil {
+		t.Fatal("Guess() returned no error for an invalid word")
+	}
+
+	if n := len(g.Guesses()); n != 0 {
+		t.Fatalf("Guesses() has %d guesses after an invalid guess, want 0", n)
+	}
+}
+
+func TestHardMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		guesses []string
+		wantErr *game.HardModeError
+	}{
+		{
+			name:    "keeps correct and present letters",
+			target:  "CIGAR",
+			guesses: []string{"CRANE", "CHAIR"},
+		},
+		{
+			name:    "moves a correct letter",
+			target:  "CIGAR",
+			guesses: []string{"CRANE", "RACKS"},
+			wantErr: &game.HardModeError{Letter: 'C', Position: 0},
+		},
+		{
+			name:    "drops a present letter",
+			target:  "CIGAR",
+			guesses: []string{"CRANE", "CLAMP"},
+			wantErr: &game.HardModeError{Letter: 'R', Position: -1},
+		},
+		{
+			name:    "uses only one of two present repeated letters",
+			target:  "LLAMA",
+			guesses: []string{"ALLEY", "BLAND"},
+			wantErr: &game.HardModeError{Letter: 'L', Position: -1},
+		},
+		{
+			name:    "checks hints of every previous guess",
+			target:  "CIGAR",
+			guesses: []string{"CRANE", "CHAIR", "CLEAR"},
+			wantErr: &game.HardModeError{Letter: 'I', Position: -1},
+		},
+		{
+			name:    "absent letters can be used again",
+			target:  "CIGAR",
+			guesses: []string{"CRANE", "CAREN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game.New(tt.target, game.Options{HardMode: true})
+
+			var err error
+			for _, guess := range tt.guesses {
+				_, err = g.Guess(guess)
+				if err != nil {
+					break
+				}
+			}
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Guess() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var hardErr *game.HardModeError
+			if !errors.As(err, &hardErr) {
+				t.Fatalf("Guess() error = %v, want %v", err, tt.wantErr)
+			}
+			if *hardErr != *tt.wantErr {
+				t.Fatalf("Guess() error = %+v, want %+v", *hardErr, *tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHardModeErrorMessages(t *testing.T) {
+	tests := []struct {
+		err  *game.HardModeError
+		want string
+	}{
+		{err: &game.HardModeError{Letter: 'C', Position: 0}, want: "1st letter must be C"},
+		{err: &game.HardModeError{Letter: 'E', Position: 4}, want: "5th letter must be E"},
+		{err: &game.HardModeError{Letter: 'R', Position: -1}, want: "guess must contain R"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestWinAndLose(t *testing.T) {
+	won := game.New("cigar", game.Options{})
+	for _, guess := range []string{"crane", "cigar"} {
+		if _, err := won.Guess(guess); err != nil {
+			t.Fatalf("Guess(%q) error = %v", guess, err)
+		}
+	}
+	if !won.Won() || !won.Over() {
+		t.Fatalf("Won() = %v, Over() = %v after guessing the word, want true, true", won.Won(), won.Over())
+	}
+	if won.Score() != "2/6" {
+		t.Fatalf("Score() = %q, want %q", won.Score(), "2/6")
+	}
+
+	lost := game.New("cigar", game.Options{HardMode: true})
+	for i := 0; i < game.DefaultMaxGuesses; i++ {
+		if _, err := lost.Guess("blunt"); err != nil {
+			t.Fatalf("Guess() error = %v", err)
+		}
+	}
+	if lost.Won() || !lost.Over() {
+		t.Fatalf("Won() = %v, Over() = %v after running out of guesses, want false, true", lost.Won(), lost.Over())
+	}
+	if lost.Score() != "X/6*" {
+		t.Fatalf("Score() = %q, want %q", lost.Score(), "X/6*")
+	}
+}
+
+func TestAbsentLetters(t *testing.T) {
+	g := game.New("cigar", game.Options{})
+	for _, guess := range []string{"crane", "blunt"} {
+		if _, err := g.Guess(guess); err != nil {
+			t.Fatalf("Guess(%q) error = %v", guess, err)
+		}
+	}
+
+	if got, want := string(g.AbsentLetters()), "NEBLUT"; got != want {
+		t.Fatalf("AbsentLetters() = %q, want %q", got, want)
+	}
+}
+
+func TestShareIsParsedAsCopyPaste(t *testing.T) {
+	tests := []struct {
+		name     string
+		hardMode bool
+		guesses  []string
+		want     wordle.Attempt
+	}{
+		{
+			name:    "won",
+			guesses: []string{"crane", "chair", "cigar"},
+			want: wordle.Attempt{
+				Day:            217,
+				MaxAttempts:    6,
+				Attempts:       3,
+				Success:        true,
+				AttemptsDetail: []string{"🟩🟨🟨⬛⬛", "🟩⬛🟨🟨🟩", "🟩🟩🟩🟩🟩"},
+			},
+		},
+		{
+			name:     "lost in hard mode",
+			hardMode: true,
+			guesses:  []string{"blunt", "blunt", "blunt", "blunt", "blunt", "blunt"},
+			want: wordle.Attempt{
+				Day:         217,
+				MaxAttempts: 6,
+				Attempts:    6,
+				Success:     false,
+				HardMode:    true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := game.New("cigar", game.Options{HardMode: tt.hardMode})
+			for _, guess := range tt.guesses {
+				if _, err := g.Guess(guess); err != nil {
+					t.Fatalf("Guess(%q) error = %v", guess, err)
+				}
+			}
+
+			got, ok := wordle.ParseCopyPaste(g.Share("Wordle 217"))
+			if !ok {
+				t.Fatalf("ParseCopyPaste() failed to parse %q", g.Share("Wordle 217"))
+			}
+
+			if got.Day != tt.want.Day || got.MaxAttempts != tt.want.MaxAttempts || got.Attempts != tt.want.Attempts ||
+				got.Success != tt.want.Success || got.HardMode != tt.want.HardMode {
+				t.Fatalf("ParseCopyPaste() = %+v, want %+v", got, tt.want)
+			}
+
+			for i, line := range tt.want.AttemptsDetail {
+				if got.AttemptsDetail[i] != line {
+					t.Fatalf("ParseCopyPaste() line %d = %q, want %q", i, got.AttemptsDetail[i], line)
+				}
+			}
+		})
+	}
+}
+
+func resultsEqual(a, b []game.LetterResult) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -6,6 +6,7 @@ require (
 	github.com/bwmarrin/discordgo v0.25.0
 	github.com/golang-migrate/migrate/v4 v4.15.2
 	github.com/sirupsen/logrus v1.8.1
+	golang.org/x/image v0.0.0-20220722155232-062f8c9fd539
 	gorm.io/driver/postgres v1.3.8
 	gorm.io/gorm v1.23.8
 )
@@ -1,21 +1,57 @@
 package main
 
 import (
+	"flag"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
+	_ "time/tzdata"
 
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
 	"github.com/andrerfcsantos/wordle-discord-bot/wordlebot"
 	log "github.com/sirupsen/logrus"
 )
 
 func main() {
-	bot, err := wordlebot.New(&wordlebot.Config{
+	syncCommandsOnly := flag.Bool("sync-commands-only", false,
+		"register the application commands of the bot, removing stale ones, and exit without starting it")
+	flag.Parse()
+
+	err := wordle.LoadWordLists(os.Getenv("WORDLE_ANSWERS_FILE"), os.Getenv("WORDLE_GUESSES_FILE"))
+	if err != nil {
+		panic("error loading word lists: " + err.Error())
+	}
+
+	err = render.LoadFallbackFonts(strings.Split(os.Getenv("WORDLE_FALLBACK_FONTS"), ","))
+	if err != nil {
+		panic("error loading fallback fonts: " + err.Error())
+	}
+
+	jobWorkers, _ := strconv.Atoi(os.Getenv("WORDLE_JOB_WORKERS"))
+	skipHistoricalReactions, _ := strconv.ParseBool(os.Getenv("WORDLE_SKIP_HISTORICAL_REACTIONS"))
+
+	config := &wordlebot.Config{
 		Token:             os.Getenv("WORDLE_DISCORD_BOT_TOKEN"),
 		AppID:             os.Getenv("WORDLE_DISCORD_BOT_APP_ID"),
 		InteractionGuilds: strings.Split(os.Getenv("WORDLE_DISCORD_BOT_INTERACTION_GUILDS"), ","),
-	})
+		JobWorkers:        jobWorkers,
+
+		SkipHistoricalReactions: skipHistoricalReactions,
+	}
+
+	if *syncCommandsOnly {
+		err = wordlebot.SyncCommands(config)
+		if err != nil {
+			panic("error syncing application commands: " + err.Error())
+		}
+		log.Info("Application commands are in sync.")
+		return
+	}
+
+	bot, err := wordlebot.New(config)
 	if err != nil {
 		panic("error creating new bot: " + err.Error())
 	}
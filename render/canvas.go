@@ -0,0 +1,220 @@
+// Package render draws images for the bot, like leaderboards and charts, in pure Go.
+package render
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// Colors of the theme, close to the ones of the discord dark theme so images blend in with the chat.
+var (
+	Background = color.RGBA{R: 0x2f, G: 0x31, B: 0x36, A: 0xff}
+	RowStripe  = color.RGBA{R: 0x36, G: 0x39, B: 0x3f, A: 0xff}
+	Text       = color.RGBA{R: 0xdc, G: 0xdd, B: 0xde, A: 0xff}
+	MutedText  = color.RGBA{R: 0x96, G: 0x98, B: 0x9d, A: 0xff}
+	Green      = color.RGBA{R: 0x6a, G: 0xaa, B: 0x64, A: 0xff}
+	Yellow     = color.RGBA{R: 0xc9, G: 0xb4, B: 0x58, A: 0xff}
+	Grey       = color.RGBA{R: 0x78, G: 0x7c, B: 0x7e, A: 0xff}
+	Gold       = color.RGBA{R: 0xf1, G: 0xc4, B: 0x0f, A: 0xff}
+	Silver     = color.RGBA{R: 0xbd, G: 0xc3, B: 0xc7, A: 0xff}
+	Bronze     = color.RGBA{R: 0xcd, G: 0x7f, B: 0x32, A: 0xff}
+)
+
+// avatarColors are the background colors of avatars, picked by user.
+var avatarColors = []color.RGBA{
+	{R: 0x58, G: 0x65, B: 0xf2, A: 0xff},
+	{R: 0x3b, G: 0xa5, B: 0x5d, A: 0xff},
+	{R: 0xfa, G: 0xa6, B: 0x1a, A: 0xff},
+	{R: 0xed, G: 0x42, B: 0x45, A: 0xff},
+	{R: 0xeb, G: 0x45, B: 0x9e, A: 0xff},
+	{R: 0x1a, G: 0xbc, B: 0x9c, A: 0xff},
+	{R: 0x9b, G: 0x59, B: 0xb6, A: 0xff},
+	{R: 0xe6, G: 0x7e, B: 0x22, A: 0xff},
+}
+
+var regularFont, boldFont *opentype.Font
+
+func init() {
+	regularFont = mustParseFont(goregular.TTF)
+	boldFont = mustParseFont(gobold.TTF)
+}
+
+func mustParseFont(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(fmt.Sprintf("parsing bundled font: %v", err))
+	}
+	return f
+}
+
+// newFace creates a face of a font that draws the characters the font doesn't have with the fallback fonts.
+func newFace(f *opentype.Font, size float64) font.Face {
+	fonts := append([]*opentype.Font{f}, fallbackFonts...)
+	faces := make([]font.Face, len(fonts))
+	for n, fnt := range fonts {
+		face, err := opentype.NewFace(fnt, &opentype.FaceOptions{
+			Size:    size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			panic(fmt.Sprintf("creating font face: %v", err))
+		}
+		faces[n] = face
+	}
+	return newFallbackFace(fonts, faces)
+}
+
+// canvas is an image being drawn, with helpers for the shapes used by the bot images.
+type canvas struct {
+	img   *image.RGBA
+	faces map[textStyle]font.Face
+}
+
+func newCanvas(width int, height int, background color.Color) *canvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	return &canvas{img: img, faces: make(map[textStyle]font.Face)}
+}
+
+// Image returns the image drawn so far.
+func (c *canvas) Image() image.Image {
+	return c.img
+}
+
+func (c *canvas) fillRect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// fill fills the path built by the given function on a rasterizer the size of the canvas.
+func (c *canvas) fill(col color.Color, path func(z *vector.Rasterizer)) {
+	b := c.img.Bounds()
+	z := vector.NewRasterizer(b.Dx(), b.Dy())
+	path(z)
+	z.Draw(c.img, b, image.NewUniform(col), image.Point{})
+}
+
+// circle fills a circle centered at (cx, cy).
+func (c *canvas) circle(cx float32, cy float32, radius float32, col color.Color) {
+	// Control point distance to approximate a quarter of a circle with a cubic bezier curve.
+	const k = 0.5522847498
+	r, kr := radius, radius*k
+
+	c.fill(col, func(z *vector.Rasterizer) {
+		z.MoveTo(cx+r, cy)
+		z.CubeTo(cx+r, cy+kr, cx+kr, cy+r, cx, cy+r)
+		z.CubeTo(cx-kr, cy+r, cx-r, cy+kr, cx-r, cy)
+		z.CubeTo(cx-r, cy-kr, cx-kr, cy-r, cx, cy-r)
+		z.CubeTo(cx+kr, cy-r, cx+r, cy-kr, cx+r, cy)
+		z.ClosePath()
+	})
+}
+
+// line strokes a straight line with the given width.
+func (c *canvas) line(x0, y0, x1, y1 float32, width float32, col color.Color) {
+	dx, dy := x1-x0, y1-y0
+	length := float32(math.Hypot(float64(dx), float64(dy)))
+	if length == 0 {
+		return
+	}
+
+	// Offset perpendicular to the line by half of the width on each side.
+	nx, ny := -dy/length*width/2, dx/length*width/2
+
+	c.fill(col, func(z *vector.Rasterizer) {
+		z.MoveTo(x0+nx, y0+ny)
+		z.LineTo(x1+nx, y1+ny)
+		z.LineTo(x1-nx, y1-ny)
+		z.LineTo(x0-nx, y0-ny)
+		z.ClosePath()
+	})
+}
+
+// text draws text with its baseline starting at (x, y).
+func (c *canvas) text(face font.Face, x int, y int, s string, col color.Color) {
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// textRight draws text with its baseline ending at (x, y).
+func (c *canvas) textRight(face font.Face, x int, y int, s string, col color.Color) {
+	c.text(face, x-textWidth(face, s), y, s, col)
+}
+
+// textCenter draws text centered horizontally at x and vertically at y.
+func (c *canvas) textCenter(face font.Face, x int, y int, s string, col color.Color) {
+	m := face.Metrics()
+	baseline := y + (m.Ascent.Ceil()-m.Descent.Ceil())/2
+	c.text(face, x-textWidth(face, s)/2, baseline, s, col)
+}
+
+func (c *canvas) label(x int, y int, s string, style textStyle, a align, col color.Color) {
+	face, ok := c.faces[style]
+	if !ok {
+		f := regularFont
+		if style.Bold {
+			f = boldFont
+		}
+		face = newFace(f, style.Size)
+		c.faces[style] = face
+	}
+
+	switch a {
+	case alignCenter:
+		c.text(face, x-textWidth(face, s)/2, y, s, col)
+	case alignRight:
+		c.textRight(face, x, y, s, col)
+	default:
+		c.text(face, x, y, s, col)
+	}
+}
+
+func textWidth(face font.Face, s string) int {
+	return font.MeasureString(face, s).Ceil()
+}
+
+// truncate shortens text with an ellipsis so that it fits in the given width.
+func truncate(face font.Face, s string, width int) string {
+	if textWidth(face, s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	for n := len(runes) - 1; n > 0; n-- {
+		t := string(runes[:n]) + "…"
+		if textWidth(face, t) <= width {
+			return t
+		}
+	}
+	return "…"
+}
+
+// avatarColor picks the avatar background color of a user. The same key always gets the same color.
+func avatarColor(key string) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return avatarColors[h.Sum32()%uint32(len(avatarColors))]
+}
+
+// EncodePNG writes an image in PNG format.
+func EncodePNG(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
@@ -0,0 +1,244 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+)
+
+var (
+	Red   = color.RGBA{R: 0xd9, G: 0x53, B: 0x4f, A: 0xff}
+	Empty = color.RGBA{R: 0x40, G: 0x44, B: 0x4b, A: 0xff}
+)
+
+var (
+	titleStyle = textStyle{Size: 24, Bold: true}
+	smallStyle = textStyle{Size: 13}
+	axisStyle  = textStyle{Size: 13, Bold: true}
+	valueStyle = textStyle{Size: 15, Bold: true}
+)
+
+const chartPadding = 24
+
+// chartHeader draws the title and subtitle of a chart and returns where the chart body starts.
+func chartHeader(s surface, title string, subtitle string) int {
+	s.label(chartPadding, 40, title, titleStyle, alignLeft, Text)
+	if subtitle != "" {
+		s.label(chartPadding, 62, subtitle, smallStyle, alignLeft, MutedText)
+	}
+	return 84
+}
+
+// DistributionChart draws a histogram of the number of guesses of the games won, with a bar for each number of
+// guesses starting at 1, and a last bar for the games lost.
+func DistributionChart(title string, subtitle string, won []int, lost int) Chart {
+	const (
+		width     = 600
+		barHeight = 28
+		barGap    = 8
+		labelX    = chartPadding + 12
+		barX      = chartPadding + 32
+		maxBar    = width - barX - chartPadding - 40
+	)
+
+	bars := append(append([]int(nil), won...), lost)
+	height := 84 + len(bars)*(barHeight+barGap) + chartPadding
+
+	return Chart{width: width, height: height, draw: func(s surface) {
+		top := chartHeader(s, title, subtitle)
+
+		max := 0
+		for _, n := range bars {
+			if n > max {
+				max = n
+			}
+		}
+
+		for i, n := range bars {
+			y := top + i*(barHeight+barGap)
+			baseline := y + barHeight/2 + 5
+
+			label, col := fmt.Sprint(i+1), Green
+			if i == len(bars)-1 {
+				label, col = "X", Red
+			}
+
+			length := 0
+			if max > 0 {
+				length = n * maxBar / max
+			}
+			if length < 4 {
+				length, col = 4, Grey
+			}
+
+			s.label(labelX, baseline, label, axisStyle, alignCenter, MutedText)
+			s.fillRect(image.Rect(barX, y, barX+length, y+barHeight), col)
+			s.label(barX+length+8, baseline, fmt.Sprint(n), valueStyle, alignLeft, Text)
+		}
+	}}
+}
+
+// TrendPoint is a point of a trend chart.
+type TrendPoint struct {
+	Day   int
+	Value float64
+}
+
+// TrendChart draws a line chart of values between min and max, in order. The days of the first and last points
+// label the horizontal axis.
+func TrendChart(title string, subtitle string, points []TrendPoint, min float64, max float64) Chart {
+	const (
+		width  = 720
+		height = 360
+		left   = chartPadding + 28
+		right  = width - chartPadding
+		bottom = height - chartPadding - 20
+	)
+
+	return Chart{width: width, height: height, draw: func(s surface) {
+		top := chartHeader(s, title, subtitle) + 8
+
+		y := func(v float64) float32 {
+			return float32(bottom) - float32((v-min)/(max-min))*float32(bottom-top)
+		}
+		x := func(n int) float32 {
+			if len(points) < 2 {
+				return float32(left+right) / 2
+			}
+			return float32(left) + float32(n)*float32(right-left)/float32(len(points)-1)
+		}
+
+		for v := min; v <= max; v++ {
+			gy := y(v)
+			s.line(left, gy, right, gy, 1, RowStripe)
+			s.label(left-10, int(gy)+5, fmt.Sprint(v), axisStyle, alignRight, MutedText)
+		}
+
+		if len(points) == 0 {
+			s.label((left+right)/2, (top+bottom)/2, "Not enough games yet.", valueStyle, alignCenter, MutedText)
+			return
+		}
+
+		for n := 1; n < len(points); n++ {
+			s.line(x(n-1), y(points[n-1].Value), x(n), y(points[n].Value), 3, Green)
+		}
+		if len(points) == 1 {
+			s.circle(x(0), y(points[0].Value), 4, Green)
+		}
+
+		s.label(left, bottom+24, fmt.Sprintf("Wordle %d", points[0].Day), smallStyle, alignLeft, MutedText)
+		if len(points) > 1 {
+			s.label(right, bottom+24, fmt.Sprintf("Wordle %d", points[len(points)-1].Day), smallStyle, alignRight,
+				MutedText)
+		}
+	}}
+}
+
+// HeatmapDay is a day of a heatmap chart.
+type HeatmapDay struct {
+	Date   time.Time
+	Played bool
+	Won    bool
+	// Attempts is the number of guesses of a game won, which sets how bright the day is.
+	Attempts int
+}
+
+// HeatmapChart draws a calendar with a column per week, Monday first, coloring the days played by result. Days
+// must be consecutive and in order.
+func HeatmapChart(title string, subtitle string, days []HeatmapDay) Chart {
+	const (
+		cell   = 13
+		gap    = 3
+		left   = chartPadding + 34
+		legend = 40
+	)
+
+	width := left + heatmapWeeks(days)*(cell+gap) + chartPadding
+	if width < 480 {
+		width = 480
+	}
+	height := 84 + 20 + 7*(cell+gap) + legend + chartPadding
+
+	return Chart{width: width, height: height, draw: func(s surface) {
+		top := chartHeader(s, title, subtitle) + 20
+
+		for _, wd := range []struct {
+			row  int
+			name string
+		}{{0, "Mon"}, {2, "Wed"}, {4, "Fri"}} {
+			s.label(left-8, top+wd.row*(cell+gap)+cell-2, wd.name, smallStyle, alignRight, MutedText)
+		}
+
+		if len(days) == 0 {
+			return
+		}
+
+		offset := weekdayIndex(days[0].Date)
+		for n, d := range days {
+			week, weekday := (offset+n)/7, (offset+n)%7
+			x, y := left+week*(cell+gap), top+weekday*(cell+gap)
+
+			// Months are labeled above the week of their first Monday.
+			if weekday == 0 && d.Date.Day() <= 7 {
+				s.label(x, top-8, d.Date.Format("Jan"), smallStyle, alignLeft, MutedText)
+			}
+
+			s.fillRect(image.Rect(x, y, x+cell, y+cell), heatmapColor(d))
+		}
+
+		legendY := top + 7*(cell+gap) + 16
+		x := left
+		for _, item := range []struct {
+			col  color.Color
+			name string
+		}{
+			{Empty, "not played"},
+			{heatmapColor(HeatmapDay{Played: true, Won: true, Attempts: 6}), "won"},
+			{heatmapColor(HeatmapDay{Played: true, Won: true, Attempts: 2}), "won in few guesses"},
+			{Red, "lost"},
+		} {
+			s.fillRect(image.Rect(x, legendY, x+cell, legendY+cell), item.col)
+			s.label(x+cell+6, legendY+cell-2, item.name, smallStyle, alignLeft, MutedText)
+			x += cell + 6 + 8*len(item.name) + 16
+		}
+	}}
+}
+
+// heatmapWeeks returns the number of columns of a heatmap of the given days.
+func heatmapWeeks(days []HeatmapDay) int {
+	if len(days) == 0 {
+		return 0
+	}
+	return (weekdayIndex(days[0].Date)+len(days)-1)/7 + 1
+}
+
+// weekdayIndex returns the row of a date in the heatmap, starting with Monday.
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+func heatmapColor(d HeatmapDay) color.Color {
+	switch {
+	case !d.Played:
+		return Empty
+	case !d.Won:
+		return Red
+	}
+
+	// Blend from a dark green for 6 guesses to the theme green for 1 guess.
+	t := float64(6-d.Attempts) / 5
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	dark := color.RGBA{R: 0x2e, G: 0x55, B: 0x2b, A: 0xff}
+	return color.RGBA{
+		R: uint8(float64(dark.R) + t*float64(int(Green.R)-int(dark.R))),
+		G: uint8(float64(dark.G) + t*float64(int(Green.G)-int(dark.G))),
+		B: uint8(float64(dark.B) + t*float64(int(Green.B)-int(dark.B))),
+		A: 0xff,
+	}
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestChartsEncode(t *testing.T) {
+	start := time.Date(2022, time.January, 19, 0, 0, 0, 0, time.UTC)
+	days := []HeatmapDay{
+		{Date: start, Played: true, Won: true, Attempts: 3},
+		{Date: start.AddDate(0, 0, 1)},
+		{Date: start.AddDate(0, 0, 2), Played: true},
+	}
+
+	charts := map[string]Chart{
+		"distribution":       DistributionChart("Guess distribution", "alice", []int{0, 1, 4, 2, 0, 1}, 1),
+		"empty distribution": DistributionChart("Guess distribution", "", make([]int, 6), 0),
+		"trend":              TrendChart("Trend", "alice", []TrendPoint{{Day: 1, Value: 4}, {Day: 2, Value: 3.5}}, 1, 7),
+		"single point trend": TrendChart("Trend", "", []TrendPoint{{Day: 1, Value: 4}}, 1, 7),
+		"empty trend":        TrendChart("Trend", "", nil, 1, 7),
+		"heatmap":            HeatmapChart("Calendar", "alice <&>", days),
+		"empty heatmap":      HeatmapChart("Calendar", "", nil),
+	}
+
+	for name, chart := range charts {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := chart.Encode(&buf, FormatPNG); err != nil {
+				t.Fatalf("Encode(png) error = %v", err)
+			}
+			if _, err := png.Decode(&buf); err != nil {
+				t.Fatalf("decoding rendered PNG: %v", err)
+			}
+
+			buf.Reset()
+			if err := chart.Encode(&buf, FormatSVG); err != nil {
+				t.Fatalf("Encode(svg) error = %v", err)
+			}
+			decoder := xml.NewDecoder(&buf)
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatalf("rendered SVG is not valid XML: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestHeatmapWeeks(t *testing.T) {
+	// 2022-01-16 was a sunday
+	sunday := time.Date(2022, time.January, 16, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		start time.Time
+		days  int
+		want  int
+	}{
+		{name: "no days", start: sunday, days: 0, want: 0},
+		{name: "a sunday", start: sunday, days: 1, want: 1},
+		{name: "sunday to monday", start: sunday, days: 2, want: 2},
+		{name: "sunday to the second monday", start: sunday, days: 9, want: 3},
+		{name: "a full week from monday", start: sunday.AddDate(0, 0, 1), days: 7, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var days []HeatmapDay
+			for n := 0; n < tt.days; n++ {
+				days = append(days, HeatmapDay{Date: tt.start.AddDate(0, 0, n)})
+			}
+
+			if got := heatmapWeeks(days); got != tt.want {
+				t.Fatalf("heatmapWeeks() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// fallbackFonts draw the characters the Go fonts don't have, like CJK characters and emoji, in the order they are
+// tried.
+var fallbackFonts []*opentype.Font
+
+// LoadFallbackFonts loads the fonts (.ttf, .otf or .ttc collections) used for the characters the bundled Go fonts
+// don't have, tried in order. It must be called before drawing any images.
+func LoadFallbackFonts(paths []string) error {
+	var fonts []*opentype.Font
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading font %s: %w", path, err)
+		}
+
+		collection, err := opentype.ParseCollection(data)
+		if err != nil {
+			return fmt.Errorf("parsing font %s: %w", path, err)
+		}
+		for n := 0; n < collection.NumFonts(); n++ {
+			f, err := collection.Font(n)
+			if err != nil {
+				return fmt.Errorf("parsing font %d of %s: %w", n, path, err)
+			}
+			fonts = append(fonts, f)
+		}
+	}
+
+	fallbackFonts = fonts
+	return nil
+}
+
+// fallbackFace is a font face that draws each character with the first of its fonts that has a glyph for it.
+// Characters none of the fonts have are drawn with the first one, as boxes, except for invisible ones like variation
+// selectors and zero width joiners, which are left out.
+type fallbackFace struct {
+	fonts []*opentype.Font
+	faces []font.Face
+	buf   sfnt.Buffer
+}
+
+func newFallbackFace(fonts []*opentype.Font, faces []font.Face) *fallbackFace {
+	return &fallbackFace{fonts: fonts, faces: faces}
+}
+
+// face returns the face that draws r, and whether any of the fonts has a glyph for it.
+func (f *fallbackFace) face(r rune) (font.Face, bool) {
+	for n, fnt := range f.fonts {
+		if index, err := fnt.GlyphIndex(&f.buf, r); err == nil && index != 0 {
+			return f.faces[n], true
+		}
+	}
+	return f.faces[0], false
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6,
+	bool) {
+	face, found := f.face(r)
+	if !found && invisible(r) {
+		return image.Rectangle{}, nil, image.Point{}, 0, false
+	}
+	return face.Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	face, found := f.face(r)
+	if !found && invisible(r) {
+		return fixed.Rectangle26_6{}, 0, false
+	}
+	return face.GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	face, found := f.face(r)
+	if !found && invisible(r) {
+		return 0, false
+	}
+	return face.GlyphAdvance(r)
+}
+
+// Kern only kerns characters drawn with the same font.
+func (f *fallbackFace) Kern(r0, r1 rune) fixed.Int26_6 {
+	face0, _ := f.face(r0)
+	face1, _ := f.face(r1)
+	if face0 != face1 {
+		return 0
+	}
+	return face0.Kern(r0, r1)
+}
+
+func (f *fallbackFace) Metrics() font.Metrics {
+	return f.faces[0].Metrics()
+}
+
+func (f *fallbackFace) Close() error {
+	var err error
+	for _, face := range f.faces {
+		if closeErr := face.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// invisible tells whether a character has no glyph of its own, but changes how the characters around it look, like
+// the variation selectors, zero width joiners and skin tones of emoji.
+func invisible(r rune) bool {
+	return unicode.In(r, unicode.Cf, unicode.Variation_Selector) || r >= 0x1f3fb && r <= 0x1f3ff
+}
@@ -0,0 +1,43 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestFallbackFace(t *testing.T) {
+	face := newFace(regularFont, 18)
+
+	if got, want := textWidth(face, "👍\U0001f3fd‍️"), textWidth(face, "👍"); got != want {
+		t.Errorf("textWidth() with invisible characters = %d, want %d", got, want)
+	}
+	if textWidth(face, "田") == 0 {
+		t.Errorf("textWidth() of a character no font has = 0, want the width of a box")
+	}
+}
+
+func TestLoadFallbackFonts(t *testing.T) {
+	defer func() { fallbackFonts = nil }()
+
+	path := filepath.Join(t.TempDir(), "fallback.ttf")
+	if err := os.WriteFile(path, goregular.TTF, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadFallbackFonts([]string{path, ""}); err != nil {
+		t.Fatalf("LoadFallbackFonts() error = %v", err)
+	}
+	if len(fallbackFonts) != 1 {
+		t.Fatalf("LoadFallbackFonts() loaded %d fonts, want 1", len(fallbackFonts))
+	}
+	if face := newFace(regularFont, 18).(*fallbackFace); len(face.faces) != 2 {
+		t.Errorf("newFace() has %d faces, want the font and its fallback", len(face.faces))
+	}
+
+	if err := LoadFallbackFonts([]string{filepath.Join(t.TempDir(), "missing.ttf")}); err == nil {
+		t.Errorf("LoadFallbackFonts() of a missing file succeeded, want an error")
+	}
+}
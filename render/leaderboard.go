@@ -0,0 +1,199 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// LeaderboardRow is a player in a rendered leaderboard.
+type LeaderboardRow struct {
+	Rank int
+	// UserId picks the color of the avatar of the player.
+	UserId      string
+	Name        string
+	Score       float64
+	AvgAttempts float64
+	Played      int
+	// Trend has a value per day, oldest first, drawn as a sparkline. Days without a value are NaN.
+	Trend []float64
+	// Highlight draws the row with a border, e.g. for the player that asked for the leaderboard.
+	Highlight bool
+}
+
+const (
+	leaderboardWidth        = 760
+	leaderboardPadding      = 24
+	leaderboardHeaderHeight = 96
+	leaderboardRowHeight    = 56
+	avatarRadius            = 18
+	sparklineWidth          = 140
+	sparklineHeight         = 30
+)
+
+// Leaderboard draws a leaderboard table with a title. Characters of names the Go fonts don't have, like emoji or CJK
+// characters, are drawn with the fallback fonts, and columns stay aligned whatever the names are.
+func Leaderboard(title string, subtitle string, rows []LeaderboardRow) image.Image {
+	height := leaderboardHeaderHeight + len(rows)*leaderboardRowHeight + leaderboardPadding
+	if len(rows) == 0 {
+		height += leaderboardRowHeight
+	}
+
+	c := newCanvas(leaderboardWidth, height, Background)
+
+	titleFace := newFace(boldFont, 26)
+	headerFace := newFace(boldFont, 13)
+	nameFace := newFace(boldFont, 18)
+	valueFace := newFace(regularFont, 17)
+	rankFace := newFace(boldFont, 20)
+	initialsFace := newFace(boldFont, 16)
+
+	c.text(titleFace, leaderboardPadding, 40, title, Text)
+	if subtitle != "" {
+		c.text(headerFace, leaderboardPadding, 62, subtitle, MutedText)
+	}
+
+	// Column positions
+	var (
+		rankX      = leaderboardPadding + 18
+		avatarX    = leaderboardPadding + 60
+		nameX      = avatarX + avatarRadius + 14
+		scoreX     = 430
+		avgX       = 505
+		playedX    = 575
+		sparklineX = leaderboardWidth - leaderboardPadding - sparklineWidth
+	)
+
+	headerY := leaderboardHeaderHeight - 10
+	c.text(headerFace, nameX, headerY, "PLAYER", MutedText)
+	c.textRight(headerFace, scoreX, headerY, "SCORE", MutedText)
+	c.textRight(headerFace, avgX, headerY, "AVG", MutedText)
+	c.textRight(headerFace, playedX, headerY, "PLAYED", MutedText)
+	c.text(headerFace, sparklineX, headerY, "LAST 30 DAYS", MutedText)
+
+	if len(rows) == 0 {
+		c.text(valueFace, nameX, leaderboardHeaderHeight+leaderboardRowHeight/2+6, "Nobody played yet.", MutedText)
+	}
+
+	for n, row := range rows {
+		top := leaderboardHeaderHeight + n*leaderboardRowHeight
+		middle := top + leaderboardRowHeight/2
+		baseline := middle + 6
+
+		rowRect := image.Rect(leaderboardPadding/2, top, leaderboardWidth-leaderboardPadding/2, top+leaderboardRowHeight)
+		if n%2 == 0 {
+			c.fillRect(rowRect, RowStripe)
+		}
+		if row.Highlight {
+			c.border(rowRect, 2, Green)
+		}
+
+		c.textCenter(rankFace, rankX, middle, fmt.Sprint(row.Rank), rankColor(row.Rank))
+
+		c.circle(float32(avatarX), float32(middle), avatarRadius, avatarColor(row.UserId))
+		c.textCenter(initialsFace, avatarX, middle, Initials(row.Name), color.White)
+
+		c.text(nameFace, nameX, baseline, truncate(nameFace, row.Name, scoreX-nameX-70), Text)
+		c.textRight(valueFace, scoreX, baseline, fmt.Sprintf("%.1f", row.Score), Text)
+		c.textRight(valueFace, avgX, baseline, fmt.Sprintf("%.2f", row.AvgAttempts), Text)
+		c.textRight(valueFace, playedX, baseline, fmt.Sprint(row.Played), Text)
+
+		c.sparkline(image.Rect(sparklineX, middle-sparklineHeight/2, sparklineX+sparklineWidth, middle+sparklineHeight/2),
+			row.Trend, Green)
+	}
+
+	return c.Image()
+}
+
+func rankColor(rank int) color.Color {
+	switch rank {
+	case 1:
+		return Gold
+	case 2:
+		return Silver
+	case 3:
+		return Bronze
+	default:
+		return MutedText
+	}
+}
+
+// Initials returns up to two letters that identify a name in its avatar: the first letters of the first two words,
+// or the first letter if the name has a single word.
+func Initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var initials []rune
+	for _, w := range words {
+		initials = append(initials, unicode.ToUpper([]rune(w)[0]))
+		if len(initials) == 2 {
+			break
+		}
+	}
+
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// border draws the outline of a rectangle.
+func (c *canvas) border(r image.Rectangle, width int, col color.Color) {
+	c.fillRect(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), col)
+	c.fillRect(image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), col)
+	c.fillRect(image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y), col)
+	c.fillRect(image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y), col)
+}
+
+// sparkline draws values as a line inside r, scaled between the minimum and maximum values. NaN values leave a gap
+// in the line; values without neighbours are drawn as dots.
+func (c *canvas) sparkline(r image.Rectangle, values []float64, col color.Color) {
+	if len(values) == 0 {
+		return
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	if math.IsInf(min, 1) {
+		return
+	}
+
+	point := func(n int, v float64) (float32, float32) {
+		x := float64(r.Min.X)
+		if len(values) > 1 {
+			x += float64(n) * float64(r.Dx()) / float64(len(values)-1)
+		}
+		y := float64(r.Min.Y+r.Max.Y) / 2
+		if max > min {
+			y = float64(r.Max.Y) - (v-min)/(max-min)*float64(r.Dy())
+		}
+		return float32(x), float32(y)
+	}
+
+	for n, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		x, y := point(n, v)
+
+		connected := false
+		if n > 0 && !math.IsNaN(values[n-1]) {
+			px, py := point(n-1, values[n-1])
+			c.line(px, py, x, y, 2, col)
+			connected = true
+		}
+		if !connected && (n == len(values)-1 || math.IsNaN(values[n+1])) {
+			c.circle(x, y, 2, col)
+		}
+	}
+}
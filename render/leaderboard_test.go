@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"testing"
+)
+
+func TestInitials(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "alice", want: "A"},
+		{name: "bob smith", want: "BS"},
+		{name: "mary_jane_watson", want: "MJ"},
+		{name: "ça va", want: "ÇV"},
+		{name: "🐱 cat", want: "C"},
+		{name: "🐱", want: "?"},
+		{name: "田中", want: "田"},
+	}
+
+	for _, tt := range tests {
+		if got := Initials(tt.name); got != tt.want {
+			t.Errorf("Initials(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	face := newFace(regularFont, 18)
+
+	if got := truncate(face, "alice", 200); got != "alice" {
+		t.Fatalf("truncate() = %q, want text that fits untouched", got)
+	}
+
+	long := "a very long username that does not fit"
+	got := truncate(face, long, 100)
+	if textWidth(face, got) > 100 || []rune(got)[len([]rune(got))-1] != '…' {
+		t.Fatalf("truncate() = %q, want text ending with an ellipsis no wider than 100px", got)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	nan := math.NaN()
+	rows := []LeaderboardRow{
+		{Rank: 1, UserId: "1", Name: "alice", Score: 120.5, AvgAttempts: 3.5, Played: 30, Trend: []float64{18, 27, nan, 11}},
+		{Rank: 2, UserId: "2", Name: "🐱 bob 田中", Score: 80, AvgAttempts: 4.1, Played: 20, Trend: []float64{nan, 11}},
+		{Rank: 3, UserId: "3", Name: "carol", Score: 10, AvgAttempts: 6, Played: 2, Highlight: true},
+	}
+
+	img := Leaderboard("Leaderboard", "#wordle", rows)
+
+	wantHeight := leaderboardHeaderHeight + len(rows)*leaderboardRowHeight + leaderboardPadding
+	if b := img.Bounds(); b.Dx() != leaderboardWidth || b.Dy() != wantHeight {
+		t.Fatalf("Leaderboard() size = %dx%d, want %dx%d", b.Dx(), b.Dy(), leaderboardWidth, wantHeight)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodePNG(&buf, img); err != nil {
+		t.Fatalf("EncodePNG() error = %v", err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Fatalf("decoding rendered PNG: %v", err)
+	}
+}
+
+func TestEmptyLeaderboard(t *testing.T) {
+	img := Leaderboard("Leaderboard", "", nil)
+	if img.Bounds().Dy() <= leaderboardHeaderHeight {
+		t.Fatalf("Leaderboard() height = %d, want room for an empty message", img.Bounds().Dy())
+	}
+}
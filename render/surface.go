@@ -0,0 +1,139 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"io"
+)
+
+type align int
+
+const (
+	alignLeft align = iota
+	alignCenter
+	alignRight
+)
+
+type textStyle struct {
+	Size float64
+	Bold bool
+}
+
+// surface is where charts are drawn, either an image or an SVG document.
+type surface interface {
+	fillRect(r image.Rectangle, col color.Color)
+	line(x0, y0, x1, y1 float32, width float32, col color.Color)
+	circle(cx float32, cy float32, radius float32, col color.Color)
+	// label draws text with its baseline at y, aligned horizontally to x.
+	label(x int, y int, s string, style textStyle, a align, col color.Color)
+}
+
+// Format is a file format charts can be encoded in.
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// ContentType returns the MIME type of files in the format.
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Chart is a chart that can be encoded in any Format.
+type Chart struct {
+	width  int
+	height int
+	draw   func(s surface)
+}
+
+// Image draws the chart as an image.
+func (c Chart) Image() image.Image {
+	cv := newCanvas(c.width, c.height, Background)
+	c.draw(cv)
+	return cv.Image()
+}
+
+// SVG draws the chart as an SVG document.
+func (c Chart) SVG() []byte {
+	s := newSVGCanvas(c.width, c.height, Background)
+	c.draw(s)
+	return s.Bytes()
+}
+
+// Encode writes the chart in the given format.
+func (c Chart) Encode(w io.Writer, format Format) error {
+	if format == FormatSVG {
+		_, err := w.Write(c.SVG())
+		return err
+	}
+	return EncodePNG(w, c.Image())
+}
+
+// svgCanvas builds an SVG document with the same shapes a canvas draws.
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+func newSVGCanvas(width int, height int, background color.Color) *svgCanvas {
+	s := &svgCanvas{}
+	fmt.Fprintf(&s.buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	s.fillRect(image.Rect(0, 0, width, height), background)
+	return s
+}
+
+// Bytes returns the document, closing it.
+func (s *svgCanvas) Bytes() []byte {
+	return append(s.buf.Bytes(), "</svg>\n"...)
+}
+
+func (s *svgCanvas) fillRect(r image.Rectangle, col color.Color) {
+	fmt.Fprintf(&s.buf, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n",
+		r.Min.X, r.Min.Y, r.Dx(), r.Dy(), svgPaint("fill", col))
+}
+
+func (s *svgCanvas) line(x0, y0, x1, y1 float32, width float32, col color.Color) {
+	fmt.Fprintf(&s.buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke-width="%.1f" %s/>`+"\n",
+		x0, y0, x1, y1, width, svgPaint("stroke", col))
+}
+
+func (s *svgCanvas) circle(cx float32, cy float32, radius float32, col color.Color) {
+	fmt.Fprintf(&s.buf, `<circle cx="%.1f" cy="%.1f" r="%.1f" %s/>`+"\n", cx, cy, radius, svgPaint("fill", col))
+}
+
+func (s *svgCanvas) label(x int, y int, text string, style textStyle, a align, col color.Color) {
+	anchor := "start"
+	switch a {
+	case alignCenter:
+		anchor = "middle"
+	case alignRight:
+		anchor = "end"
+	}
+
+	weight := "normal"
+	if style.Bold {
+		weight = "bold"
+	}
+
+	fmt.Fprintf(&s.buf, `<text x="%d" y="%d" font-family="Go, sans-serif" font-size="%g" font-weight="%s" `+
+		`text-anchor="%s" %s>%s</text>`+"\n",
+		x, y, style.Size, weight, anchor, svgPaint("fill", col), html.EscapeString(text))
+}
+
+// svgPaint returns the attributes to paint with a color, with its opacity if it's not opaque.
+func svgPaint(attr string, col color.Color) string {
+	c := color.NRGBAModel.Convert(col).(color.NRGBA)
+	paint := fmt.Sprintf(`%s="#%02x%02x%02x"`, attr, c.R, c.G, c.B)
+	if c.A != 0xff {
+		paint += fmt.Sprintf(` %s-opacity="%.2f"`, attr, float64(c.A)/0xff)
+	}
+	return paint
+}
@@ -0,0 +1,138 @@
+package wordle
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// answersData has the answers of the wordle puzzles in order, one per line, starting with day 0.
+//
+//go:embed data/answers.txt
+var answersData string
+
+// guessesData has the words accepted as guesses that are never answers, one per line.
+//
+//go:embed data/guesses.txt
+var guessesData string
+
+var answers []string
+var validGuesses map[string]bool
+
+var spoilerTagRegex *regexp.Regexp
+
+func init() {
+	setWordLists(parseWordList(answersData), parseWordList(guessesData))
+	spoilerTagRegex = regexp.MustCompile(`(?s)\|\|.*?\|\|`)
+}
+
+func setWordLists(answerList []string, guessList []string) {
+	answers = answerList
+	validGuesses = make(map[string]bool, len(answerList)+len(guessList))
+	for _, w := range answerList {
+		validGuesses[w] = true
+	}
+	for _, w := range guessList {
+		validGuesses[w] = true
+	}
+}
+
+// LoadWordLists replaces the bundled answer and guess lists with the ones in the given files, in the same format
+// as the bundled ones. An empty path keeps the bundled list. Must be called before the lists are used.
+func LoadWordLists(answersPath string, guessesPath string) error {
+	answerList, guessList := answers, parseWordList(guessesData)
+
+	if answersPath != "" {
+		data, err := os.ReadFile(answersPath)
+		if err != nil {
+			return fmt.Errorf("reading answers file: %w", err)
+		}
+		answerList = parseWordList(string(data))
+	}
+
+	if guessesPath != "" {
+		data, err := os.ReadFile(guessesPath)
+		if err != nil {
+			return fmt.Errorf("reading guesses file: %w", err)
+		}
+		guessList = parseWordList(string(data))
+	}
+
+	for _, w := range append(answerList, guessList...) {
+		if utf8.RuneCountInString(w) != 5 {
+			return fmt.Errorf("invalid word %q in word lists: words must have 5 letters", w)
+		}
+	}
+
+	setWordLists(answerList, guessList)
+	return nil
+}
+
+// parseWordList parses a list of words, one per line, ignoring empty lines and lines starting with #.
+func parseWordList(data string) []string {
+	var words []string
+	for _, line := range strings.Split(data, "\n") {
+		word := strings.ToLower(strings.TrimSpace(line))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// Answer returns the answer to the wordle puzzle of the given day, in lowercase.
+// Returns false if the answer for that day is not known.
+func Answer(day int) (string, bool) {
+	if day < 0 || day >= len(answers) {
+		return "", false
+	}
+	return answers[day], true
+}
+
+// AnswerCount returns the number of days with known answers.
+func AnswerCount() int {
+	return len(answers)
+}
+
+// IsValidGuess returns true if the word, in any case, is in the answer or guess lists.
+func IsValidGuess(word string) bool {
+	return validGuesses[strings.ToLower(word)]
+}
+
+// wordRegex returns a regex that matches word as a whole word, case-insensitively.
+func wordRegex(word string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`)
+}
+
+// RevealsWord returns true if the text has the given word outside of spoiler tags (||like this||).
+func RevealsWord(text string, word string) bool {
+	if word == "" {
+		return false
+	}
+	visible := spoilerTagRegex.ReplaceAllString(text, "")
+	return wordRegex(word).MatchString(visible)
+}
+
+// HideWord wraps every occurrence of word in the text that is not yet inside spoiler tags with spoiler tags.
+func HideWord(text string, word string) string {
+	if word == "" {
+		return text
+	}
+
+	re := wordRegex(word)
+
+	var builder strings.Builder
+	last := 0
+	for _, loc := range spoilerTagRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(re.ReplaceAllString(text[last:loc[0]], "||$0||"))
+		builder.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	builder.WriteString(re.ReplaceAllString(text[last:], "||$0||"))
+
+	return builder.String()
+}
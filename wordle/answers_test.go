@@ -0,0 +1,102 @@
+package wordle_test
+
+import (
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+func TestAnswer(t *testing.T) {
+	tests := []struct {
+		name   string
+		day    int
+		want   string
+		wantOk bool
+	}{
+		{name: "first wordle", day: 0, want: "cigar", wantOk: true},
+		{name: "later wordle", day: 217, want: "wince", wantOk: true},
+		{name: "negative day", day: -1, wantOk: false},
+		{name: "day after the end of the list", day: 100000, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := wordle.Answer(tt.day)
+			if ok != tt.wantOk || got != tt.want {
+				t.Fatalf("Answer(%d) = %q, %v, want %q, %v", tt.day, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestRevealsWord(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{name: "word alone", text: "cigar", want: true},
+		{name: "word in a sentence", text: "Today's was CIGAR, so easy", want: true},
+		{name: "word inside another word", text: "cigars are bad for you", want: false},
+		{name: "word inside spoiler tags", text: "it was ||cigar||", want: false},
+		{name: "word inside and outside spoiler tags", text: "||cigar|| cigar", want: true},
+		{name: "no word", text: "Wordle 0 3/6", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wordle.RevealsWord(tt.text, "cigar"); got != tt.want {
+				t.Fatalf("RevealsWord(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHideWord(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "word alone", text: "cigar", want: "||cigar||"},
+		{name: "keeps the case", text: "It was Cigar!", want: "It was ||Cigar||!"},
+		{name: "already hidden", text: "||cigar|| and cigar", want: "||cigar|| and ||cigar||"},
+		{name: "word inside another word", text: "cigars", want: "cigars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wordle.HideWord(tt.text, "cigar")
+			if got != tt.want {
+				t.Fatalf("HideWord(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if wordle.RevealsWord(got, "cigar") {
+				t.Fatalf("RevealsWord(HideWord(%q)) = true, want false", tt.text)
+			}
+		})
+	}
+}
+
+func TestIsValidGuess(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{word: "cigar", want: true},
+		{word: "CIGAR", want: true},
+		{word: "adieu", want: true},
+		{word: "tares", want: true},
+		{word: "xylyl", want: true},
+		{word: "zzzzz", want: false},
+		{word: "cigars", want: false},
+		{word: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := wordle.IsValidGuess(tt.word); got != tt.want {
+				t.Fatalf("IsValidGuess(%q) = %v, want %v", tt.word, got, tt.want)
+			}
+		})
+	}
+}
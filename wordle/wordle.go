@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -73,3 +74,18 @@ func ParseCopyPaste(paste string) (*Attempt, bool) {
 		HardMode:       hardMode,
 	}, true
 }
+
+// firstDay is the date of the first wordle puzzle, day 0.
+var firstDay = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// DayForDate returns the number of the wordle puzzle of the calendar date of t, in the location of t.
+func DayForDate(t time.Time) int {
+	year, month, day := t.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return int(date.Sub(firstDay).Hours() / 24)
+}
+
+// DateForDay returns the calendar date in which the wordle puzzle with the given number was played.
+func DateForDay(day int) time.Time {
+	return firstDay.AddDate(0, 0, day)
+}
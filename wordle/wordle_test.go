@@ -2,6 +2,7 @@ package wordle_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
 )
@@ -138,7 +139,6 @@ Bot, be good`,
 					"🟩🟩🟩🟩🟩",
 				},
 				HardMode: true,
-				Score:    25,
 			},
 			wantErr: false,
 		},
@@ -197,3 +197,46 @@ func attemptsEqual(a, b *wordle.Attempt) bool {
 	}
 	return true
 }
+
+func TestDayForDate(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{
+			name: "first wordle",
+			date: time.Date(2021, time.June, 19, 12, 0, 0, 0, time.UTC),
+			want: 0,
+		},
+		{
+			name: "start of the day",
+			date: time.Date(2022, time.January, 22, 0, 0, 0, 0, time.UTC),
+			want: 217,
+		},
+		{
+			name: "end of the day",
+			date: time.Date(2022, time.January, 22, 23, 59, 59, 0, time.UTC),
+			want: 217,
+		},
+		{
+			name: "uses the date in the location of the time",
+			date: time.Date(2022, time.January, 22, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			want: 217,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wordle.DayForDate(tt.date)
+			if got != tt.want {
+				t.Fatalf("DayForDate() = %d, want %d", got, tt.want)
+			}
+
+			date := wordle.DateForDay(got)
+			if y, m, d := tt.date.Date(); date.Year() != y || date.Month() != m || date.Day() != d {
+				t.Fatalf("DateForDay(%d) = %v, want date of %v", got, date, tt.date)
+			}
+		})
+	}
+}
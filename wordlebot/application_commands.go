@@ -1,16 +1,33 @@
 package wordlebot
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
 	"github.com/bwmarrin/discordgo"
 	log "github.com/sirupsen/logrus"
 )
 
-func (b *WordleBot) setupApplicationCommands() error {
-	command := &discordgo.ApplicationCommand{
+// Names of the context menu commands, shown as they are in the menus of members and messages.
+const (
+	userStatsCommand    = "Wordle stats"
+	recordResultCommand = "Record Wordle result"
+)
+
+// wordleCommand is the definition of the /wordle chat command, with all its subcommands.
+func wordleCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
 		Name:        "wordle",
 		Type:        discordgo.ChatApplicationCommand,
 		Description: "Wordle stats",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "help",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Lists the commands of the bot and how the current channel is set up.",
+			},
 			{
 				Name:        "track",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
@@ -19,27 +36,468 @@ func (b *WordleBot) setupApplicationCommands() error {
 			{
 				Name:        "leaderboard",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
-				Description: "Displays the leaderboard for the current channel.",
+				Description: "Displays the leaderboard for the current channel, or for the whole server.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "scope",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Whose results count. Defaults to this channel.",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "this channel", Value: leaderboardScopeChannel},
+							{Name: "every tracked channel of this server", Value: leaderboardScopeServer},
+						},
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "teams",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Displays the standings of the teams of the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "method",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "How the scores of the members of a team add up. Defaults to the channel setting.",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "sum: the scores of all the members", Value: TeamScoringSum},
+							{Name: "top: the average of the best members", Value: TeamScoringTop},
+							{Name: "weighted: the average of the members, weighted by games played", Value: TeamScoringWeighted},
+						},
+					},
+					{
+						Name:        "top",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Description: "Members per team the top method counts. Defaults to the channel setting.",
+						MinValue:    &minTeamTop,
+						MaxValue:    maxTeamTop,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "stats",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Displays the stats of a player in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "user",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Description:  "The player. Defaults to you.",
+						Autocomplete: true,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "versus",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Compares two players over the days both played in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "player1",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Description:  "The first player.",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:         "player2",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Description:  "The second player.",
+						Required:     true,
+						Autocomplete: true,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "day",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Displays the results of a wordle in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "day",
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Description:  "Number of the wordle.",
+						Required:     true,
+						MinValue:     &minDay,
+						Autocomplete: true,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "answer",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Reveals, in spoiler tags, the answer of a past wordle.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "day",
+						Type:         discordgo.ApplicationCommandOptionInteger,
+						Description:  "Number of the wordle.",
+						Required:     true,
+						MinValue:     &minDay,
+						Autocomplete: true,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "missing",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Lists the regulars of the current channel that haven't posted today's wordle yet.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "min_days",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Description: fmt.Sprintf("Days a player must have posted in to be a regular. Defaults to %d.", defaultRegularMinDays),
+						MinValue:    &minRegularDays,
+					},
+					{
+						Name:        "days",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Description: fmt.Sprintf("Number of past days to look at. Defaults to %d.", defaultRegularDays),
+						MinValue:    &minRegularDays,
+						MaxValue:    maxRegularDays,
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "reactions",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Chooses how the bot reacts to wordle copy/pastes in the current channel. Admins only.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "set",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Reaction set to use.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "classic: ✅ for every copy/paste", Value: ReactionSetClassic},
+							{Name: "results: 🎯 1️⃣ 💀 for results, 🔥 for streaks, 👑 for first place", Value: ReactionSetResults},
+							{Name: "none: no reactions", Value: ReactionSetNone},
+						},
+					},
+				},
+			},
+			{
+				Name:        "spoilers",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Chooses what to do with messages revealing today's answer in the current channel. Admins only.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "mode",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Spoiler guard mode.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "off: do nothing", Value: SpoilerGuardOff},
+							{Name: "delete: delete the message", Value: SpoilerGuardDelete},
+							{Name: "spoiler: post the message again with the answer in spoiler tags", Value: SpoilerGuardSpoiler},
+							{Name: "warn: ask the author to hide the answer", Value: SpoilerGuardWarn},
+						},
+					},
+				},
+			},
+			{
+				Name:        "summary",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Schedules a daily summary of yesterday's results in the current channel. Admins only.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "time",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Time of the day to post the summary, in HH:MM format, or \"off\" to stop posting it.",
+						Required:    true,
+					},
+					{
+						Name:        "timezone",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Timezone of the channel, e.g. Europe/Lisbon. Defaults to the current one (UTC if unset).",
+					},
+				},
+			},
+			{
+				Name:        "recap",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Enables or disables weekly and monthly recaps with awards in the current channel. Admins only.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "weekly",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Description: "Post a recap every monday for the previous week.",
+						Required:    true,
+					},
+					{
+						Name:        "monthly",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Description: "Post a recap on the first day of every month for the previous month.",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "remind",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Description: "Reminders to post the wordle of the day in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "on",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Reminds you if you haven't posted today's wordle in this channel by a given time.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "time",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Description: "Time of the day to remind you, in HH:MM format. Defaults to " + defaultReminderTime + ".",
+							},
+							{
+								Name:        "timezone",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Description: "Your timezone, e.g. Europe/Lisbon. Defaults to the timezone of the channel.",
+							},
+							{
+								Name:        "via",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Description: "How to remind you. Defaults to a direct message.",
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "direct message", Value: db.ReminderDeliveryDM},
+									{Name: "mention in this channel", Value: db.ReminderDeliveryMention},
+								},
+							},
+						},
+					},
+					{
+						Name:        "off",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Stops reminding you to post in this channel.",
+					},
+				},
+			},
+			{
+				Name:        "visibility",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Chooses whether read-only commands answer publicly by default in the current channel. Admins only.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "public",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Description: "Show responses to everyone in the channel, unless the command says otherwise.",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "config",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Description: "Settings of the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "get",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Shows the settings of this channel.",
+						Options:     []*discordgo.ApplicationCommandOption{settingOption(false)},
+					},
+					{
+						Name:        "set",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Changes a setting of this channel. Admins only.",
+						Options: []*discordgo.ApplicationCommandOption{
+							settingOption(true),
+							{
+								Name:         "value",
+								Type:         discordgo.ApplicationCommandOptionString,
+								Description:  "The new value of the setting.",
+								Required:     true,
+								Autocomplete: true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "team-roles",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Description: "Roles whose members compete as teams in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "add",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Makes the members of a role compete as a team in this channel. Admins only.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "role",
+								Type:        discordgo.ApplicationCommandOptionRole,
+								Description: "The role of the team.",
+								Required:    true,
+							},
+						},
+					},
+					{
+						Name:        "remove",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Stops a role from competing as a team in this channel. Admins only.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "role",
+								Type:        discordgo.ApplicationCommandOptionRole,
+								Description: "The role of the team.",
+								Required:    true,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "jobs",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Displays the status of the background jobs for the current channel.",
+				Options:     []*discordgo.ApplicationCommandOption{publicOption},
+			},
+			{
+				Name:        "play",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Play today's wordle here. Your result is posted in the channel when you finish.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "hard",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Description: "Play in hard mode: revealed hints must be used in later guesses.",
+					},
+				},
+			},
+			{
+				Name:        "chart",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Draws a chart of the results of a player in the current channel.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "type",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "What to chart.",
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "distribution: how many guesses games took", Value: ChartDistribution},
+							{Name: "trend: rolling average of guesses over time", Value: ChartTrend},
+							{Name: "heatmap: calendar of days played, won and lost", Value: ChartHeatmap},
+						},
+					},
+					{
+						Name:         "user",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Description:  "The player. Defaults to you.",
+						Autocomplete: true,
+					},
+					{
+						Name:        "format",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Description: "Image format. Defaults to PNG.",
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "PNG", Value: string(render.FormatPNG)},
+							{Name: "SVG", Value: string(render.FormatSVG)},
+						},
+					},
+					publicOption,
+				},
+			},
+			{
+				Name:        "practice",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Practices with the word of a random past wordle. Results don't count for the leaderboard.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "hard",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Description: "Play in hard mode: revealed hints must be used in later guesses.",
+					},
+				},
+			},
+			{
+				Name:        "practice-stats",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Description: "Shows your practice stats.",
+				Options:     []*discordgo.ApplicationCommandOption{publicOption},
+			},
+			{
+				Name:        "challenge",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Description: "Custom puzzles set by members of the channel for others to solve.",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "create",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Sets a secret word for others in this channel to guess.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "word",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Description: "The secret word. Only you will see it.",
+								Required:    true,
+							},
+							{
+								Name:        "user",
+								Type:        discordgo.ApplicationCommandOptionUser,
+								Description: "The only member allowed to solve the challenge. Defaults to anyone in the channel.",
+							},
+						},
+					},
+					{
+						Name:        "solve",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Plays a challenge set in this channel.",
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "id",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Description: "Number of the challenge.",
+								Required:    true,
+								MinValue:    &minChallengeId,
+							},
+							{
+								Name:        "hard",
+								Type:        discordgo.ApplicationCommandOptionBoolean,
+								Description: "Play in hard mode: revealed hints must be used in later guesses.",
+							},
+						},
+					},
+					{
+						Name:        "leaderboard",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Description: "Prints the leaderboard of the challenges of the current channel.",
+						Options:     []*discordgo.ApplicationCommandOption{publicOption},
+					},
+				},
 			},
 		},
 	}
+}
 
-	for _, guild := range b.config.InteractionGuilds {
-		cmd, err := b.session.ApplicationCommandCreate(
-			b.config.AppID,
-			guild,
-			command,
-		)
-		if err != nil {
-			log.Errorf("creating application command for guild %q: %v\n", guild, err)
-		}
-
-		if guild == "" {
-			b.appCmd = cmd
-		}
+// contextMenuCommands are the commands in the menus of members and messages.
+func contextMenuCommands() []*discordgo.ApplicationCommand {
+	return []*discordgo.ApplicationCommand{
+		{
+			Name: userStatsCommand,
+			Type: discordgo.UserApplicationCommand,
+		},
+		{
+			Name: recordResultCommand,
+			Type: discordgo.MessageApplicationCommand,
+		},
+	}
+}
+
+func (b *WordleBot) setupApplicationCommands() error {
+	err := syncApplicationCommands(b.session, b.config.AppID, interactionGuilds(b.config.InteractionGuilds))
+	if err != nil {
+		return err
 	}
 
 	b.session.AddHandler(b.ApplicationCommandHandler)
+	b.session.AddHandler(b.AutocompleteHandler)
+	b.session.AddHandler(b.ModalSubmitHandler)
+	b.session.AddHandler(b.MessageComponentHandler)
 	b.session.AddHandler(b.DeleteMessageHandler)
 	b.session.AddHandler(b.UpdateMessageHandler)
 
@@ -54,14 +512,132 @@ func (b *WordleBot) ApplicationCommandHandler(s *discordgo.Session, i *discordgo
 	data := i.ApplicationCommandData()
 
 	var err error
+	switch data.Name {
+	case "wordle":
+		err = b.handleWordleCommand(s, i)
+	case userStatsCommand:
+		err = b.HandleUserStatsInteraction(s, i)
+	case recordResultCommand:
+		err = b.HandleRecordResultInteraction(s, i)
+	}
+
+	if err != nil {
+		log.Errorf("responding to interaction: %v\n", err)
+	}
+}
+
+// handleWordleCommand dispatches the subcommands of the /wordle chat command.
+func (b *WordleBot) handleWordleCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 {
+		return fmt.Errorf("wordle command without a subcommand")
+	}
+
 	switch data.Options[0].Name {
+	case "help":
+		return b.HandleHelpInteraction(s, i)
 	case "track":
-		err = b.HandleTrackInteraction(s, i)
+		return b.HandleTrackInteraction(s, i)
 	case "leaderboard":
-		err = b.HandleLeaderboardInteraction(s, i)
+		return b.HandleLeaderboardInteraction(s, i)
+	case "teams":
+		return b.HandleTeamsInteraction(s, i)
+	case "stats":
+		return b.HandleStatsInteraction(s, i)
+	case "versus":
+		return b.HandleVersusInteraction(s, i)
+	case "day":
+		return b.HandleDayInteraction(s, i)
+	case "answer":
+		return b.HandleAnswerInteraction(s, i)
+	case "missing":
+		return b.HandleMissingInteraction(s, i)
+	case "reactions":
+		return b.HandleReactionsInteraction(s, i)
+	case "spoilers":
+		return b.HandleSpoilersInteraction(s, i)
+	case "summary":
+		return b.HandleSummaryInteraction(s, i)
+	case "recap":
+		return b.HandleRecapInteraction(s, i)
+	case "remind":
+		return b.HandleRemindInteraction(s, i)
+	case "visibility":
+		return b.HandleVisibilityInteraction(s, i)
+	case "config":
+		return b.HandleConfigInteraction(s, i)
+	case "team-roles":
+		return b.HandleTeamRolesInteraction(s, i)
+	case "jobs":
+		return b.HandleJobsInteraction(s, i)
+	case "play":
+		return b.HandlePlayInteraction(s, i)
+	case "challenge":
+		return b.HandleChallengeInteraction(s, i)
+	case "chart":
+		return b.HandleChartInteraction(s, i)
+	case "practice":
+		return b.HandlePracticeInteraction(s, i)
+	case "practice-stats":
+		return b.HandlePracticeStatsInteraction(s, i)
+	}
+
+	return nil
+}
+
+func (b *WordleBot) MessageComponentHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	data := i.MessageComponentData()
+
+	var err error
+	switch {
+	case strings.HasPrefix(data.CustomID, leaderboardButtonPrefix):
+		err = b.HandleLeaderboardButton(s, i)
 	}
 
 	if err != nil {
-		log.Errorf("responding to interaction: %v\n", err)
+		log.Errorf("responding to message component: %v\n", err)
+	}
+}
+
+// subCommandOptions returns the options given to the subcommand of a chat command interaction, by name.
+// Subcommands inside subcommand groups are supported.
+func subCommandOptions(i *discordgo.InteractionCreate) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption)
+
+	opts := i.ApplicationCommandData().Options
+	for len(opts) > 0 && isSubCommand(opts[0]) {
+		opts = opts[0].Options
+	}
+
+	for _, opt := range opts {
+		options[opt.Name] = opt
+	}
+
+	return options
+}
+
+// subCommandGroupCommand returns the name of the subcommand used inside a subcommand group.
+func subCommandGroupCommand(i *discordgo.InteractionCreate) string {
+	opts := i.ApplicationCommandData().Options
+	if len(opts) == 0 || len(opts[0].Options) == 0 {
+		return ""
+	}
+	return opts[0].Options[0].Name
+}
+
+func isSubCommand(opt *discordgo.ApplicationCommandInteractionDataOption) bool {
+	return opt.Type == discordgo.ApplicationCommandOptionSubCommand ||
+		opt.Type == discordgo.ApplicationCommandOptionSubCommandGroup
+}
+
+// interactionUser returns the user that triggered an interaction, both in guilds and in DMs.
+func interactionUser(i *discordgo.InteractionCreate) *discordgo.User {
+	if i.Member != nil {
+		return i.Member.User
 	}
+	return i.User
 }
@@ -0,0 +1,20 @@
+package wordlebot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestHandleWordleCommandWithoutSubcommand(t *testing.T) {
+	// Context menu commands have no options, so only chat commands can be dispatched by subcommand
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "wordle"},
+	}}
+
+	b := &WordleBot{}
+	if err := b.handleWordleCommand(nil, i); err == nil {
+		t.Fatal("handleWordleCommand() error = nil, want an error for a command without a subcommand")
+	}
+}
@@ -0,0 +1,191 @@
+package wordlebot
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxChoices is the most autocomplete suggestions discord accepts.
+const maxChoices = 25
+
+// userIdRegex matches the ids of users given as player options, either bare or as mentions.
+var userIdRegex = regexp.MustCompile(`^<@!?(\d+)>$|^(\d+)$`)
+
+func (b *WordleBot) AutocompleteHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+
+	opt := focusedOption(i)
+	if opt == nil {
+		return
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	var err error
+	switch opt.Name {
+	case "day":
+		to := latestStartedDay(time.Now())
+		if i.ApplicationCommandData().Options[0].Name == "answer" {
+			to = lastFinishedDay(time.Now())
+		}
+		choices, err = b.dayChoices(i.ChannelID, typedValue(opt), to)
+	case "user", "player1", "player2":
+		choices, err = b.playerChoices(i.ChannelID, typedValue(opt))
+	case "value":
+		if setting, ok := subCommandOptions(i)["setting"]; ok {
+			choices = settingValueChoices(setting.StringValue(), typedValue(opt))
+		}
+	}
+	if err != nil {
+		log.Errorf("getting autocomplete choices for option %q: %v\n", opt.Name, err)
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		log.Errorf("responding to autocomplete: %v\n", err)
+	}
+}
+
+// focusedOption returns the option being typed in an autocomplete interaction.
+func focusedOption(i *discordgo.InteractionCreate) *discordgo.ApplicationCommandInteractionDataOption {
+	for _, opt := range subCommandOptions(i) {
+		if opt.Focused {
+			return opt
+		}
+	}
+	return nil
+}
+
+// typedValue returns what was typed so far in the focused option of an autocomplete interaction.
+func typedValue(opt *discordgo.ApplicationCommandInteractionDataOption) string {
+	if opt.Value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(opt.Value))
+}
+
+// dayChoices suggests the most recent days, up to day to, whose number starts with what was typed. Only days the
+// channel played are suggested, unless there are none.
+func (b *WordleBot) dayChoices(channelId string, typed string, to int) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	days, err := b.repository.RecentDays(channelId, to, typed, maxChoices)
+	if err != nil {
+		return nil, fmt.Errorf("getting recent days: %w", err)
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, d := range days {
+		choices = append(choices, dayChoice(d.Day, d.Players))
+	}
+	if len(choices) > 0 {
+		return choices, nil
+	}
+
+	for day := to; day >= 0 && len(choices) < maxChoices; day-- {
+		if strings.HasPrefix(strconv.Itoa(day), typed) {
+			choices = append(choices, dayChoice(day, 0))
+		}
+	}
+
+	return choices, nil
+}
+
+func dayChoice(day int, players int) *discordgo.ApplicationCommandOptionChoice {
+	name := fmt.Sprintf("Wordle %d · %s", day, wordle.DateForDay(day).Format("Mon, 2 Jan 2006"))
+	switch {
+	case players == 1:
+		name += " · 1 player"
+	case players > 1:
+		name += fmt.Sprintf(" · %d players", players)
+	}
+
+	return &discordgo.ApplicationCommandOptionChoice{Name: name, Value: day}
+}
+
+// playerChoices suggests the players of a channel whose name contains what was typed.
+func (b *WordleBot) playerChoices(channelId string, typed string) ([]*discordgo.ApplicationCommandOptionChoice, error) {
+	players, err := b.repository.Players(channelId, typed, maxChoices)
+	if err != nil {
+		return nil, fmt.Errorf("getting players: %w", err)
+	}
+
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(players))
+	for _, p := range players {
+		choices = append(choices, playerChoice(p))
+	}
+
+	return choices, nil
+}
+
+func playerChoice(p db.Player) *discordgo.ApplicationCommandOptionChoice {
+	return &discordgo.ApplicationCommandOptionChoice{
+		Name:  fmt.Sprintf("%s · %d played", p.UserName, p.Played),
+		Value: p.UserId,
+	}
+}
+
+// playerOption returns the player given in a player option, which holds the user id of a suggestion, a mention or,
+// when nothing was suggested, the name of a player of the channel. Returns nil if there's no such player.
+func (b *WordleBot) playerOption(s *discordgo.Session, channelId string,
+	opt *discordgo.ApplicationCommandInteractionDataOption) (*discordgo.User, error) {
+	value := strings.TrimSpace(opt.StringValue())
+
+	userId, ok := parseUserId(value)
+	if !ok {
+		players, err := b.repository.Players(channelId, value, maxChoices)
+		if err != nil {
+			return nil, fmt.Errorf("getting players: %w", err)
+		}
+		for _, p := range players {
+			if strings.EqualFold(p.UserName, value) {
+				return &discordgo.User{ID: p.UserId, Username: p.UserName}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	player, err := b.repository.Player(channelId, userId)
+	if err != nil {
+		return nil, fmt.Errorf("getting player: %w", err)
+	}
+	if player != nil {
+		return &discordgo.User{ID: player.UserId, Username: player.UserName}, nil
+	}
+
+	// Members that never played in the channel are still found by id.
+	user, err := s.User(userId)
+	if err != nil {
+		return nil, nil
+	}
+	return user, nil
+}
+
+// parseUserId returns the user id of a bare id or a mention.
+func parseUserId(value string) (string, bool) {
+	match := userIdRegex.FindStringSubmatch(value)
+	if match == nil {
+		return "", false
+	}
+	if match[1] != "" {
+		return match[1], true
+	}
+	return match[2], true
+}
+
+func unknownPlayerEmbed(opt *discordgo.ApplicationCommandInteractionDataOption) *discordgo.MessageEmbed {
+	return newEmbed("", fmt.Sprintf("I couldn't find a player called %q in this channel.", opt.StringValue()),
+		colorWarning)
+}
@@ -0,0 +1,73 @@
+package wordlebot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestParseUserId(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   string
+		wantOk bool
+	}{
+		{"123456789", "123456789", true},
+		{"<@123456789>", "123456789", true},
+		{"<@!123456789>", "123456789", true},
+		{"alice", "", false},
+		{"<@alice>", "", false},
+	}
+
+	for _, test := range tests {
+		got, ok := parseUserId(test.value)
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("parseUserId(%q) = %q, %v, want %q, %v", test.value, got, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+func TestDayChoice(t *testing.T) {
+	tests := []struct {
+		players int
+		want    string
+	}{
+		{0, "Wordle 217 · Sat, 22 Jan 2022"},
+		{1, "Wordle 217 · Sat, 22 Jan 2022 · 1 player"},
+		{5, "Wordle 217 · Sat, 22 Jan 2022 · 5 players"},
+	}
+
+	for _, test := range tests {
+		choice := dayChoice(217, test.players)
+		if choice.Name != test.want || choice.Value != 217 {
+			t.Errorf("dayChoice(217, %d) = %q, %v, want %q, 217", test.players, choice.Name, choice.Value, test.want)
+		}
+	}
+}
+
+func TestFocusedOption(t *testing.T) {
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommandAutocomplete,
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name: "wordle",
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{
+					Name: "versus",
+					Type: discordgo.ApplicationCommandOptionSubCommand,
+					Options: []*discordgo.ApplicationCommandInteractionDataOption{
+						{Name: "player1", Type: discordgo.ApplicationCommandOptionString, Value: "1234"},
+						{Name: "player2", Type: discordgo.ApplicationCommandOptionString, Value: " al", Focused: true},
+					},
+				},
+			},
+		},
+	}}
+
+	opt := focusedOption(i)
+	if opt == nil || opt.Name != "player2" {
+		t.Fatalf("focusedOption() = %+v, want the player2 option", opt)
+	}
+	if got := typedValue(opt); got != "al" {
+		t.Fatalf("typedValue() = %q, want \"al\"", got)
+	}
+}
@@ -10,6 +10,11 @@ type Config struct {
 	Token             string
 	AppID             string
 	InteractionGuilds []string
+	// JobWorkers is the number of background jobs (e.g. channel scans) that can run at the same time.
+	JobWorkers int
+	// SkipHistoricalReactions disables reactions on copy/pastes found when scanning past messages of a channel.
+	// Live copy/pastes are always reacted to.
+	SkipHistoricalReactions bool
 }
 
 func New(config *Config) (*WordleBot, error) {
@@ -17,18 +22,21 @@ func New(config *Config) (*WordleBot, error) {
 	var err error
 
 	bot.config = *config
-	bot.config.InteractionGuilds = make([]string, len(config.InteractionGuilds))
-	copy(bot.config.InteractionGuilds, config.InteractionGuilds)
-
-	if !sliceHasString(bot.config.InteractionGuilds, "") {
-		bot.config.InteractionGuilds = append([]string{""}, bot.config.InteractionGuilds...)
-	}
 
 	bot.session, err = discordgo.New("Bot " + bot.config.Token)
 	if err != nil {
 		return nil, fmt.Errorf("creating new bot: %w", err)
 	}
 
+	bot.reactions, err = newReactionDispatcher("Bot " + bot.config.Token)
+	if err != nil {
+		return nil, fmt.Errorf("creating reaction dispatcher: %w", err)
+	}
+	bot.reactions.Start()
+
+	bot.games = newGameSessions()
+	bot.copyPastes = newRecentCopyPastes()
+
 	// bot.session.Identify.Intents = discordgo.MakeIntent(discordgo.IntentsAll)
 	bot.session.AddHandler(bot.MessageCreateHandler)
 
@@ -52,16 +60,39 @@ func New(config *Config) (*WordleBot, error) {
 		return nil, fmt.Errorf("migrating database: %w", err)
 	}
 
+	bot.settings = newSettingsCache(bot.repository.ChannelSettings)
+	bot.roles = newMemberRolesCache(bot.listMemberRoles)
+
+	go bot.backfillGuilds()
+
+	bot.jobs = newJobQueue(&bot, bot.config.JobWorkers)
+	err = bot.jobs.Start()
+	if err != nil {
+		return nil, fmt.Errorf("starting job queue: %w", err)
+	}
+
+	bot.scheduler = newScheduler(bot.postDailySummaries, bot.postRecaps, bot.sendReminders)
+	bot.scheduler.Start()
+
 	return &bot, nil
 }
 
 type WordleBot struct {
 	session    *discordgo.Session
 	repository *db.Repository
-	appCmd     *discordgo.ApplicationCommand
+	settings   *settingsCache
+	roles      *memberRolesCache
+	jobs       *jobQueue
+	reactions  *reactionDispatcher
+	scheduler  *scheduler
+	games      *gameSessions
+	copyPastes *recentCopyPastes
 	config     Config
 }
 
 func (b *WordleBot) Close() error {
+	b.scheduler.Stop()
+	b.jobs.Stop()
+	b.reactions.Stop()
 	return b.session.Close()
 }
@@ -0,0 +1,208 @@
+package wordlebot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/game"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+var minChallengeId = 1.0
+
+func (b *WordleBot) HandleChallengeInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	switch subCommandGroupCommand(i) {
+	case "create":
+		return b.HandleChallengeCreateInteraction(s, i)
+	case "solve":
+		return b.HandleChallengeSolveInteraction(s, i)
+	case "leaderboard":
+		return b.HandleChallengeLeaderboardInteraction(s, i)
+	}
+	return nil
+}
+
+func (b *WordleBot) HandleChallengeCreateInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+	options := subCommandOptions(i)
+
+	word := strings.ToLower(strings.TrimSpace(options["word"].StringValue()))
+	if utf8.RuneCountInString(word) != game.WordLength || !wordle.IsValidGuess(word) {
+		return respondEphemeral(s, i, fmt.Sprintf("`%s` is not a valid %d-letter word.", word, game.WordLength))
+	}
+
+	var challenged *discordgo.User
+	if opt, ok := options["user"]; ok {
+		challenged = opt.UserValue(s)
+		if challenged.ID == user.ID {
+			return respondEphemeral(s, i, "You can't challenge yourself!")
+		}
+	}
+
+	var challengedId string
+	if challenged != nil {
+		challengedId = challenged.ID
+	}
+
+	challenge, err := b.repository.CreateChallenge(i.ChannelID, user.ID, user.Username, challengedId, word)
+	if err != nil {
+		respondEphemeral(s, i, problemMessage)
+		return fmt.Errorf("creating challenge: %w", err)
+	}
+
+	err = respondEphemeral(s, i, fmt.Sprintf("Challenge #%d created with the word ||%s||.", challenge.Id, word))
+	if err != nil {
+		return err
+	}
+
+	who := "Anyone here"
+	mentions := &discordgo.MessageAllowedMentions{}
+	if challenged != nil {
+		who = fmt.Sprintf("<@%s>", challenged.ID)
+		mentions.Users = []string{challenged.ID}
+	}
+
+	_, err = b.session.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("🧩 <@%s> set challenge #%d! %s can solve it with `/wordle challenge solve id:%d`.",
+			user.ID, challenge.Id, who, challenge.Id),
+		AllowedMentions: mentions,
+	})
+	if err != nil {
+		return fmt.Errorf("announcing challenge: %w", err)
+	}
+
+	return nil
+}
+
+func (b *WordleBot) HandleChallengeSolveInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+	options := subCommandOptions(i)
+	id := options["id"].IntValue()
+
+	session := b.games.Get(GameKindChallenge, i.ChannelID, user.ID)
+	if session != nil && session.Challenge.Id == id {
+		return openGuessModal(s, i, session)
+	}
+
+	challenge, err := b.repository.Challenge(i.ChannelID, id)
+	if err != nil {
+		respondEphemeral(s, i, problemMessage)
+		return fmt.Errorf("getting challenge: %w", err)
+	}
+
+	switch {
+	case challenge == nil:
+		return respondEphemeral(s, i, fmt.Sprintf("There's no challenge #%d in this channel.", id))
+	case challenge.CreatorId == user.ID:
+		return respondEphemeral(s, i, "You can't solve your own challenge!")
+	case challenge.ChallengedId != "" && challenge.ChallengedId != user.ID:
+		return respondEphemeral(s, i, fmt.Sprintf("Challenge #%d is for <@%s> only.", id, challenge.ChallengedId))
+	}
+
+	played, err := b.repository.HasChallengeResult(challenge.Id, user.ID)
+	if err != nil {
+		return fmt.Errorf("checking challenge result: %w", err)
+	}
+	if played {
+		return respondEphemeral(s, i, fmt.Sprintf("You already played challenge #%d.", id))
+	}
+
+	var hardMode bool
+	if opt, ok := options["hard"]; ok {
+		hardMode = opt.BoolValue()
+	}
+
+	// A user solves one challenge at a time in each channel; starting another one abandons the previous.
+	session = &gameSession{
+		Kind:      GameKindChallenge,
+		ChannelId: i.ChannelID,
+		Challenge: challenge,
+		Game: game.New(challenge.Word, game.Options{
+			HardMode:    hardMode,
+			IsValidWord: wordle.IsValidGuess,
+		}),
+	}
+	b.games.Set(user.ID, session)
+
+	return openGuessModal(s, i, session)
+}
+
+// postChallengeResult saves the result of a finished challenge and announces it in the channel.
+func (b *WordleBot) postChallengeResult(i *discordgo.InteractionCreate, user *discordgo.User, session *gameSession) error {
+	g := session.Game
+
+	detail := strings.Split(g.Grid(), "\n")
+	attemptsJson, err := json.Marshal(detail)
+	if err != nil {
+		return fmt.Errorf("marshalling attempts detail: %w", err)
+	}
+
+	err = b.repository.SaveChallengeResult(db.ChallengeResult{
+		ChallengeId:  session.Challenge.Id,
+		UserId:       user.ID,
+		ChannelId:    i.ChannelID,
+		UserName:     user.Username,
+		Attempts:     len(g.Guesses()),
+		MaxAttempts:  g.MaxGuesses(),
+		Success:      g.Won(),
+		HardMode:     g.HardMode(),
+		AttemptsJson: string(attemptsJson),
+	})
+	if err != nil {
+		return fmt.Errorf("saving challenge result: %w", err)
+	}
+
+	verb := "solved"
+	if !g.Won() {
+		verb = "failed"
+	}
+
+	_, err = b.session.ChannelMessageSendEmbed(i.ChannelID, newEmbed("",
+		fmt.Sprintf("<@%s> %s <@%s>'s challenge:\n%s", user.ID, verb, session.Challenge.CreatorId,
+			g.Share(session.Title())),
+		resultColor(g.Won(), len(g.Guesses()))))
+	if err != nil {
+		return fmt.Errorf("posting challenge result: %w", err)
+	}
+
+	return nil
+}
+
+func (b *WordleBot) HandleChallengeLeaderboardInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	entries, err := b.repository.ChallengeLeaderboard(i.ChannelID)
+	if err != nil {
+		log.Errorf("Error handling challenge leaderboard interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	if len(entries) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", "Nobody played a challenge in this channel yet. "+
+			"Set one with `/wordle challenge create`!", colorNeutral), flags)
+	}
+
+	var builder strings.Builder
+
+	tabw := tabwriter.NewWriter(&builder, 2, 2, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(tabw, "Name\tSolved\tAvg. Attempts\tPlayed\n")
+	for _, entry := range entries {
+		fmt.Fprintf(tabw, "%s\t%d\t%.2f\t%d\n",
+			entry.Username, entry.Solved, entry.AvgAttempts, entry.Played)
+	}
+	tabw.Flush()
+
+	embed := newEmbed("Challenge leaderboard", "```\n"+builder.String()+"```", colorInfo)
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: "All challenges · Ranked by challenges solved, then by average guesses",
+	}
+
+	return respondEmbedWithFlags(s, i, embed, flags)
+}
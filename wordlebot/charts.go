@@ -0,0 +1,173 @@
+package wordlebot
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ChartDistribution = "distribution"
+	ChartTrend        = "trend"
+	ChartHeatmap      = "heatmap"
+)
+
+const (
+	// rollingAverageGames is the number of games averaged in each point of the trend chart.
+	rollingAverageGames = 7
+	// heatmapDays is the number of days shown in the heatmap chart, up to today.
+	heatmapDays = 365
+	// failedAttemptsValue is the number of attempts games lost count as in averages.
+	failedAttemptsValue = 7
+)
+
+func (b *WordleBot) HandleChartInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	options := subCommandOptions(i)
+
+	user := interactionUser(i)
+	if opt, ok := options["user"]; ok {
+		var err error
+		user, err = b.playerOption(s, i.ChannelID, opt)
+		if err != nil {
+			log.Errorf("Error handling chart interaction: %v", err)
+			respondProblem(s, i, flags)
+			return err
+		}
+		if user == nil {
+			return respondEphemeralEmbed(s, i, unknownPlayerEmbed(opt))
+		}
+	}
+
+	format := render.FormatPNG
+	if opt, ok := options["format"]; ok {
+		format = render.Format(opt.StringValue())
+	}
+
+	attempts, err := b.repository.UserAttempts(i.ChannelID, user.ID)
+	if err != nil {
+		log.Errorf("Error handling chart interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	if len(attempts) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", fmt.Sprintf("%s hasn't posted any wordle in this channel "+
+			"yet.", user.Username), colorNeutral), flags)
+	}
+
+	chartType := options["type"].StringValue()
+	chart := userChart(chartType, user.Username, attempts, time.Now().UTC())
+
+	var buf bytes.Buffer
+	err = chart.Encode(&buf, format)
+	if err != nil {
+		return fmt.Errorf("encoding chart: %w", err)
+	}
+
+	return b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: flags,
+			Files: []*discordgo.File{
+				{
+					Name:        fmt.Sprintf("%s-%s.%s", chartType, user.ID, format),
+					ContentType: format.ContentType(),
+					Reader:      &buf,
+				},
+			},
+		},
+	})
+}
+
+// userChart builds a chart of the given type from the attempts of a user, in order of day.
+func userChart(chartType string, userName string, attempts []db.Attempt, now time.Time) render.Chart {
+	switch chartType {
+	case ChartTrend:
+		return render.TrendChart("Average guesses", fmt.Sprintf("%s · rolling average of the last %d games, "+
+			"games lost count as %d", userName, rollingAverageGames, failedAttemptsValue),
+			rollingAverage(attempts, rollingAverageGames), 1, failedAttemptsValue)
+	case ChartHeatmap:
+		return render.HeatmapChart("Calendar", fmt.Sprintf("%s · last %d days", userName, heatmapDays),
+			heatmapCalendar(attempts, wordle.DayForDate(now), heatmapDays))
+	default:
+		won, lost := guessDistribution(attempts)
+		return render.DistributionChart("Guess distribution", fmt.Sprintf("%s · %d games", userName, len(attempts)),
+			won, lost)
+	}
+}
+
+// guessDistribution counts the games won with each number of guesses, starting with 1, and the games lost.
+func guessDistribution(attempts []db.Attempt) (won []int, lost int) {
+	won = make([]int, 6)
+	for _, a := range attempts {
+		if !a.Success {
+			lost++
+			continue
+		}
+		if a.Attempts < 1 {
+			continue
+		}
+		for len(won) < a.Attempts {
+			won = append(won, 0)
+		}
+		won[a.Attempts-1]++
+	}
+	return won, lost
+}
+
+// rollingAverage returns, for each attempt, the average number of guesses of it and the previous games, up to window
+// games in total.
+func rollingAverage(attempts []db.Attempt, window int) []render.TrendPoint {
+	points := make([]render.TrendPoint, 0, len(attempts))
+
+	sum := 0.0
+	for n, a := range attempts {
+		sum += attemptsValue(a)
+		if n >= window {
+			sum -= attemptsValue(attempts[n-window])
+		}
+
+		games := n + 1
+		if games > window {
+			games = window
+		}
+		points = append(points, render.TrendPoint{Day: a.Day, Value: sum / float64(games)})
+	}
+
+	return points
+}
+
+func attemptsValue(a db.Attempt) float64 {
+	if !a.Success {
+		return failedAttemptsValue
+	}
+	return float64(a.Attempts)
+}
+
+// heatmapCalendar returns the days of the heatmap chart ending on day to, with the attempts of the user.
+func heatmapCalendar(attempts []db.Attempt, to int, days int) []render.HeatmapDay {
+	from := to - days + 1
+
+	calendar := make([]render.HeatmapDay, days)
+	for n := range calendar {
+		calendar[n].Date = wordle.DateForDay(from + n)
+	}
+
+	for _, a := range attempts {
+		if a.Day < from || a.Day > to {
+			continue
+		}
+		d := &calendar[a.Day-from]
+		d.Played, d.Won, d.Attempts = true, a.Success, a.Attempts
+	}
+
+	return calendar
+}
@@ -0,0 +1,69 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
+)
+
+var chartAttempts = []db.Attempt{
+	{Day: 215, Attempts: 3, Success: true},
+	{Day: 216, Attempts: 6, Success: false},
+	{Day: 217, Attempts: 4, Success: true},
+	{Day: 219, Attempts: 3, Success: true},
+}
+
+func TestGuessDistribution(t *testing.T) {
+	won, lost := guessDistribution(chartAttempts)
+
+	want := []int{0, 0, 2, 1, 0, 0}
+	if len(won) != len(want) || lost != 1 {
+		t.Fatalf("guessDistribution() = %v, %d, want %v, 1", won, lost, want)
+	}
+	for n := range want {
+		if won[n] != want[n] {
+			t.Fatalf("guessDistribution() = %v, %d, want %v, 1", won, lost, want)
+		}
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	got := rollingAverage(chartAttempts, 2)
+	want := []render.TrendPoint{
+		{Day: 215, Value: 3},
+		{Day: 216, Value: 5},
+		{Day: 217, Value: 5.5},
+		{Day: 219, Value: 3.5},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("rollingAverage() = %v, want %v", got, want)
+	}
+	for n := range want {
+		if got[n] != want[n] {
+			t.Fatalf("rollingAverage() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestHeatmapCalendar(t *testing.T) {
+	got := heatmapCalendar(chartAttempts, 218, 3)
+
+	want := []render.HeatmapDay{
+		{Date: time.Date(2022, time.January, 21, 0, 0, 0, 0, time.UTC), Played: true, Attempts: 6},
+		{Date: time.Date(2022, time.January, 22, 0, 0, 0, 0, time.UTC), Played: true, Won: true, Attempts: 4},
+		{Date: time.Date(2022, time.January, 23, 0, 0, 0, 0, time.UTC)},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("heatmapCalendar() = %+v, want %+v", got, want)
+	}
+	for n := range want {
+		if !got[n].Date.Equal(want[n].Date) || got[n].Played != want[n].Played || got[n].Won != want[n].Won ||
+			got[n].Attempts != want[n].Attempts {
+			t.Fatalf("heatmapCalendar()[%d] = %+v, want %+v", n, got[n], want[n])
+		}
+	}
+}
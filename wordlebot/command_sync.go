@@ -0,0 +1,164 @@
+package wordlebot
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// applicationCommands are all the commands the bot registers.
+func applicationCommands() []*discordgo.ApplicationCommand {
+	return append([]*discordgo.ApplicationCommand{wordleCommand()}, contextMenuCommands()...)
+}
+
+// interactionGuilds returns the guilds to register commands in, always including the global scope, the empty guild.
+func interactionGuilds(guilds []string) []string {
+	if sliceHasString(guilds, "") {
+		return append([]string(nil), guilds...)
+	}
+	return append([]string{""}, guilds...)
+}
+
+// SyncCommands registers the application commands of the bot without starting it.
+func SyncCommands(config *Config) error {
+	session, err := discordgo.New("Bot " + config.Token)
+	if err != nil {
+		return fmt.Errorf("creating discord session: %w", err)
+	}
+
+	return syncApplicationCommands(session, config.AppID, interactionGuilds(config.InteractionGuilds))
+}
+
+// syncApplicationCommands makes the commands of each guild, and the global ones, exactly the commands the bot
+// defines, removing any other. Guilds already up to date are left untouched.
+func syncApplicationCommands(s *discordgo.Session, appId string, guilds []string) error {
+	commands := applicationCommands()
+
+	var failed []string
+	for _, guild := range guilds {
+		err := syncGuildCommands(s, appId, guild, commands)
+		if err != nil {
+			log.Errorf("syncing application commands of guild %q: %v\n", guild, err)
+			failed = append(failed, fmt.Sprintf("%q", guild))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("syncing application commands of guilds %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func syncGuildCommands(s *discordgo.Session, appId string, guild string,
+	commands []*discordgo.ApplicationCommand) error {
+	registered, err := s.ApplicationCommands(appId, guild)
+	if err != nil {
+		return fmt.Errorf("getting registered commands: %w", err)
+	}
+
+	if commandsEqual(registered, commands) {
+		log.Infof("Application commands of guild %q are up to date\n", guild)
+		return nil
+	}
+
+	registered, err = s.ApplicationCommandBulkOverwrite(appId, guild, commands)
+	if err != nil {
+		return fmt.Errorf("overwriting commands: %w", err)
+	}
+
+	log.Infof("Updated the %d application commands of guild %q\n", len(registered), guild)
+	return nil
+}
+
+// commandShape is the part of a command the bot defines, without the fields discord adds when registering it.
+type commandShape struct {
+	Type        discordgo.ApplicationCommandType
+	Name        string
+	Description string
+	Options     []optionShape
+}
+
+type optionShape struct {
+	Type         discordgo.ApplicationCommandOptionType
+	Name         string
+	Description  string
+	Required     bool
+	Autocomplete bool
+	Choices      []string
+	MinValue     *float64
+	MaxValue     float64
+	Options      []optionShape
+}
+
+// commandsEqual returns whether the registered commands are the defined ones, regardless of their order.
+func commandsEqual(registered []*discordgo.ApplicationCommand, defined []*discordgo.ApplicationCommand) bool {
+	if len(registered) != len(defined) {
+		return false
+	}
+
+	shapes := make(map[string]commandShape)
+	for _, c := range registered {
+		shapes[commandKey(c)] = newCommandShape(c)
+	}
+
+	for _, c := range defined {
+		shape, ok := shapes[commandKey(c)]
+		if !ok || !reflect.DeepEqual(shape, newCommandShape(c)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// commandKey identifies a command, as commands of different types can have the same name.
+func commandKey(c *discordgo.ApplicationCommand) string {
+	return fmt.Sprintf("%d:%s", commandType(c), c.Name)
+}
+
+// commandType returns the type of a command, which defaults to chat commands when unset.
+func commandType(c *discordgo.ApplicationCommand) discordgo.ApplicationCommandType {
+	if c.Type == 0 {
+		return discordgo.ChatApplicationCommand
+	}
+	return c.Type
+}
+
+func newCommandShape(c *discordgo.ApplicationCommand) commandShape {
+	return commandShape{
+		Type:        commandType(c),
+		Name:        c.Name,
+		Description: c.Description,
+		Options:     newOptionShapes(c.Options),
+	}
+}
+
+func newOptionShapes(options []*discordgo.ApplicationCommandOption) []optionShape {
+	if len(options) == 0 {
+		return nil
+	}
+
+	shapes := make([]optionShape, len(options))
+	for n, opt := range options {
+		shapes[n] = optionShape{
+			Type:         opt.Type,
+			Name:         opt.Name,
+			Description:  opt.Description,
+			Required:     opt.Required,
+			Autocomplete: opt.Autocomplete,
+			MinValue:     opt.MinValue,
+			MaxValue:     opt.MaxValue,
+			Options:      newOptionShapes(opt.Options),
+		}
+		// Values are compared as text, as numbers come back from discord as floats.
+		for _, choice := range opt.Choices {
+			shapes[n].Choices = append(shapes[n].Choices, fmt.Sprintf("%s=%v", choice.Name, choice.Value))
+		}
+	}
+
+	return shapes
+}
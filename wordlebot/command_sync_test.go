@@ -0,0 +1,91 @@
+package wordlebot
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// registeredCommands returns the commands as discord returns them once registered, with ids and with choice values
+// decoded from JSON.
+func registeredCommands(t *testing.T, commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	data, err := json.Marshal(commands)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var registered []*discordgo.ApplicationCommand
+	if err := json.Unmarshal(data, &registered); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for n, c := range registered {
+		c.ID = string(rune('1' + n))
+		c.ApplicationID = "1234"
+		c.Version = "1"
+	}
+
+	// Discord doesn't keep the order of the commands
+	registered[0], registered[len(registered)-1] = registered[len(registered)-1], registered[0]
+	return registered
+}
+
+func wordleCommandOf(commands []*discordgo.ApplicationCommand) *discordgo.ApplicationCommand {
+	for _, c := range commands {
+		if c.Name == "wordle" {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestCommandsEqual(t *testing.T) {
+	registered := registeredCommands(t, applicationCommands())
+
+	if !commandsEqual(registered, applicationCommands()) {
+		t.Fatal("commandsEqual() = false for the registered definitions, want true")
+	}
+}
+
+func TestCommandsEqualWithChanges(t *testing.T) {
+	tests := map[string]func(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand{
+		"removed command": func(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+			return commands[:len(commands)-1]
+		},
+		"stale command": func(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+			return append(commands, &discordgo.ApplicationCommand{Name: "old", Type: discordgo.UserApplicationCommand})
+		},
+		"changed description": func(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+			wordleCommandOf(commands).Options[0].Description = "Old description."
+			return commands
+		},
+		"removed option": func(commands []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+			for _, opt := range wordleCommandOf(commands).Options {
+				if len(opt.Options) > 0 {
+					opt.Options = opt.Options[1:]
+					break
+				}
+			}
+			return commands
+		},
+	}
+
+	for name, change := range tests {
+		registered := change(registeredCommands(t, applicationCommands()))
+		if commandsEqual(registered, applicationCommands()) {
+			t.Errorf("commandsEqual() = true with a %s, want false", name)
+		}
+	}
+}
+
+func TestInteractionGuilds(t *testing.T) {
+	got := interactionGuilds([]string{"123", "456"})
+	if len(got) != 3 || got[0] != "" || got[1] != "123" || got[2] != "456" {
+		t.Errorf("interactionGuilds() = %q, want the global scope first", got)
+	}
+
+	got = interactionGuilds([]string{""})
+	if len(got) != 1 || got[0] != "" {
+		t.Errorf("interactionGuilds() = %q, want only the global scope", got)
+	}
+}
@@ -0,0 +1,144 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// settingOption chooses a setting of the channel in the config commands.
+func settingOption(required bool) *discordgo.ApplicationCommandOption {
+	opt := &discordgo.ApplicationCommandOption{
+		Name:        "setting",
+		Type:        discordgo.ApplicationCommandOptionString,
+		Description: "The setting.",
+		Required:    required,
+	}
+	for _, s := range settingDefinitions {
+		opt.Choices = append(opt.Choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s: %s", s.Name, s.Description),
+			Value: s.Name,
+		})
+	}
+	return opt
+}
+
+func (b *WordleBot) HandleConfigInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	switch subCommandGroupCommand(i) {
+	case "get":
+		return b.HandleConfigGetInteraction(s, i)
+	case "set":
+		return b.HandleConfigSetInteraction(s, i)
+	}
+	return nil
+}
+
+func (b *WordleBot) HandleConfigGetInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		log.Errorf("Error handling config get interaction: %v", err)
+		respondProblem(s, i, uint64(discordgo.MessageFlagsEphemeral))
+		return err
+	}
+	if !tracked {
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	}
+
+	settings, err := b.settings.Get(i.ChannelID)
+	if err != nil {
+		log.Errorf("Error handling config get interaction: %v", err)
+		respondProblem(s, i, uint64(discordgo.MessageFlagsEphemeral))
+		return err
+	}
+
+	definitions := settingDefinitions
+	if opt, ok := subCommandOptions(i)["setting"]; ok {
+		definition, found := findSetting(opt.StringValue())
+		if !found {
+			return fmt.Errorf("unknown setting %q", opt.StringValue())
+		}
+		definitions = []setting{definition}
+	}
+
+	return respondEphemeralEmbed(s, i, settingsEmbed(definitions, settings))
+}
+
+func (b *WordleBot) HandleConfigSetInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	options := subCommandOptions(i)
+	name, value := options["setting"].StringValue(), options["value"].StringValue()
+
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	}
+
+	values, err := normalizeSettings(map[string]string{name: value})
+	if err != nil {
+		return respondEphemeralEmbed(s, i, newEmbed("", err.Error(), colorFailure))
+	}
+
+	err = b.setChannelSettings(i.ChannelID, values)
+	if err != nil {
+		log.Errorf("Error handling config set interaction: %v", err)
+		respondProblem(s, i, 0)
+		return err
+	}
+
+	return respondEmbed(s, i, newEmbed("", fmt.Sprintf("`%s` is now `%s` in this channel.", name, values[name]),
+		colorSuccess))
+}
+
+// settingsEmbed lists the given settings of a channel with their values, marking the ones left at their default.
+func settingsEmbed(definitions []setting, settings channelSettings) *discordgo.MessageEmbed {
+	var builder strings.Builder
+	for _, s := range definitions {
+		value := settings.Value(s.Name)
+		fmt.Fprintf(&builder, "`%s` **%s**", s.Name, value)
+		if value == s.Default {
+			builder.WriteString(" (default)")
+		}
+		fmt.Fprintf(&builder, "\n%s\n", s.Description)
+	}
+
+	embed := newEmbed("Channel settings", builder.String(), colorInfo)
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: "Admins can change them with /wordle config set",
+	}
+
+	return embed
+}
+
+// settingValueChoices suggests values of a setting that start with what was typed.
+func settingValueChoices(name string, typed string) []*discordgo.ApplicationCommandOptionChoice {
+	s, ok := findSetting(name)
+	if !ok {
+		return nil
+	}
+
+	values := s.Choices
+	if len(values) == 0 {
+		values = s.Suggestions
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), strings.ToLower(typed)) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: v, Value: v})
+		}
+	}
+	if len(choices) == 0 && typed != "" {
+		// Values that aren't suggested can still be set as typed.
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: typed, Value: typed})
+	}
+
+	return choices
+}
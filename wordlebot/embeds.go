@@ -0,0 +1,164 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Colors of the embeds in responses. Results use the colors of the tiles of the game.
+const (
+	colorSuccess = 0x6aaa64
+	colorWarning = 0xc9b458
+	colorFailure = 0xd9534f
+	colorInfo    = 0x5865f2
+	colorNeutral = 0x787c7e
+)
+
+const problemMessage = "There was a problem processing this request, sorry :("
+
+func newEmbed(title string, description string, color int) *discordgo.MessageEmbed {
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       color,
+	}
+}
+
+// scoringFooter is the footer of views with scores, explaining the scoring rule of the channel after the given text.
+func scoringFooter(text string, settings channelSettings) *discordgo.MessageEmbedFooter {
+	return &discordgo.MessageEmbedFooter{Text: text + " · " + scoringRule(settings.Scoring, settings.LeaderboardDays)}
+}
+
+// statField is an inline field showing a single stat.
+func statField(name string, value interface{}) *discordgo.MessageEmbedField {
+	return &discordgo.MessageEmbedField{Name: name, Value: fmt.Sprint(value), Inline: true}
+}
+
+// resultColor is the color of a single game: green for games won in up to 4 guesses, yellow for the harder ones and
+// red for games lost.
+func resultColor(success bool, attempts int) int {
+	switch {
+	case !success:
+		return colorFailure
+	case attempts <= 4:
+		return colorSuccess
+	default:
+		return colorWarning
+	}
+}
+
+// rateColor is the color of a set of games by the share of games won.
+func rateColor(won int, played int) int {
+	switch {
+	case played == 0:
+		return colorNeutral
+	case won*4 >= played*3:
+		return colorSuccess
+	case won*5 >= played*2:
+		return colorWarning
+	default:
+		return colorFailure
+	}
+}
+
+// winRate is the percentage of games won, rounded down.
+func winRate(won int, played int) int {
+	if played == 0 {
+		return 0
+	}
+	return won * 100 / played
+}
+
+// statsEmbed shows the stats of a player, with a bar per number of guesses of the games won.
+func statsEmbed(title string, stats playerStats) *discordgo.MessageEmbed {
+	embed := newEmbed(title, "", rateColor(stats.Won, stats.Played))
+
+	avg := "-"
+	if stats.Won > 0 {
+		avg = fmt.Sprintf("%.2f", stats.AvgGuesses())
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		statField("Played", stats.Played),
+		statField("Win %", winRate(stats.Won, stats.Played)),
+		statField("Avg. guesses", avg),
+		statField("Current streak", stats.CurrentStreak),
+		statField("Max streak", stats.MaxStreak),
+		{Name: "Guess distribution", Value: distributionBars(stats.Distribution[:])},
+	}
+
+	return embed
+}
+
+// distributionBars draws a bar of squares for each number of guesses, starting with 1, scaled to the largest one.
+func distributionBars(distribution []int) string {
+	max := 0
+	for _, n := range distribution {
+		if n > max {
+			max = n
+		}
+	}
+
+	var builder strings.Builder
+	for guesses, n := range distribution {
+		bar := 0
+		if max > 0 {
+			bar = n * 10 / max
+		}
+		if n > 0 && bar == 0 {
+			bar = 1
+		}
+		fmt.Fprintf(&builder, "`%d` %s %d\n", guesses+1, strings.Repeat("🟩", bar), n)
+	}
+
+	return builder.String()
+}
+
+func respondEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) error {
+	return respondEmbedWithFlags(s, i, embed, 0)
+}
+
+func respondEphemeralEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) error {
+	return respondEmbedWithFlags(s, i, embed, uint64(discordgo.MessageFlagsEphemeral))
+}
+
+func respondEmbedWithFlags(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed,
+	flags uint64) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  flags,
+		},
+	})
+}
+
+// deferResponse acknowledges an interaction that takes a while to answer, showing that the bot is thinking. The
+// answer is given later with editResponseEmbed.
+func deferResponse(s *discordgo.Session, i *discordgo.InteractionCreate, flags uint64) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: flags},
+	})
+}
+
+// editResponseEmbed answers a deferred interaction with an embed.
+func editResponseEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, embed *discordgo.MessageEmbed) error {
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	})
+	return err
+}
+
+// notTrackedEmbed asks to track the channel before using commands that need it.
+func notTrackedEmbed() *discordgo.MessageEmbed {
+	return newEmbed("", "This channel is not being tracked. Use `/wordle track` first.", colorWarning)
+}
+
+// respondProblem tells the user their request failed for reasons out of their control, with the flags the response
+// would have had, so problems of ephemeral responses stay ephemeral.
+func respondProblem(s *discordgo.Session, i *discordgo.InteractionCreate, flags uint64) error {
+	return respondEmbedWithFlags(s, i, newEmbed("", problemMessage, colorFailure), flags)
+}
@@ -0,0 +1,65 @@
+package wordlebot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestRateColor(t *testing.T) {
+	tests := []struct {
+		won, played int
+		want        int
+	}{
+		{0, 0, colorNeutral},
+		{3, 4, colorSuccess},
+		{2, 4, colorWarning},
+		{1, 4, colorFailure},
+	}
+
+	for _, test := range tests {
+		if got := rateColor(test.won, test.played); got != test.want {
+			t.Errorf("rateColor(%d, %d) = %#x, want %#x", test.won, test.played, got, test.want)
+		}
+	}
+}
+
+func TestDayEmbed(t *testing.T) {
+	attempts := []db.Attempt{
+		{UserName: "alice", Attempts: 3, MaxAttempts: 6, Success: true, HardMode: true},
+		{UserName: "bob", Attempts: 5, MaxAttempts: 6, Success: true},
+		{UserName: "carol", Attempts: 6, MaxAttempts: 6, Success: false},
+	}
+
+	embed := dayEmbed(217, attempts, newChannelSettings(nil))
+
+	if embed.Title != "Wordle 217" {
+		t.Errorf("dayEmbed().Title = %q, want \"Wordle 217\"", embed.Title)
+	}
+	if embed.Color != colorWarning {
+		t.Errorf("dayEmbed().Color = %#x, want %#x", embed.Color, colorWarning)
+	}
+
+	lines := strings.Split(strings.TrimSpace(embed.Description), "\n")
+	want := []string{
+		"`3/6*` alice · 18 points",
+		"`5/6` bob · 6 points",
+		"`X/6` carol · 2 points",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("dayEmbed().Description = %q, want lines %q", embed.Description, want)
+	}
+	for n := range want {
+		if lines[n] != want[n] {
+			t.Errorf("dayEmbed() line %d = %q, want %q", n, lines[n], want[n])
+		}
+	}
+
+	if got := embed.Fields[1].Value; got != "2 (66%)" {
+		t.Errorf("dayEmbed() solved field = %q, want \"2 (66%%)\"", got)
+	}
+	if !strings.HasPrefix(embed.Footer.Text, "Saturday, 22 January 2022 · ") {
+		t.Errorf("dayEmbed().Footer.Text = %q, want the date of the wordle first", embed.Footer.Text)
+	}
+}
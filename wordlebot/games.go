@@ -0,0 +1,345 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/game"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// Kinds of games played inside discord.
+const (
+	GameKindDaily     = "daily"
+	GameKindChallenge = "challenge"
+	GameKindPractice  = "practice"
+)
+
+const (
+	guessModalPrefix = "guess:"
+	guessInputId     = "guess"
+)
+
+// gameSession is a game in progress played inside discord by a user.
+type gameSession struct {
+	Kind      string
+	ChannelId string
+	// Day is the wordle day of daily games.
+	Day int
+	// Challenge is the challenge being solved in challenge games.
+	Challenge *db.Challenge
+	Game      *game.Game
+	// StartedAt is when the game was started, to drop it once it is abandoned.
+	StartedAt time.Time
+}
+
+// Title names the game in modals and results.
+func (s *gameSession) Title() string {
+	switch s.Kind {
+	case GameKindChallenge:
+		return fmt.Sprintf("Challenge #%d", s.Challenge.Id)
+	case GameKindPractice:
+		return "Practice"
+	default:
+		return fmt.Sprintf("Wordle %d", s.Day)
+	}
+}
+
+// sessionChannel returns the channel games of the given kind are kept for. Practice games follow the user
+// everywhere, so they are not kept for any channel.
+func sessionChannel(kind string, channelId string) string {
+	if kind == GameKindPractice {
+		return ""
+	}
+	return channelId
+}
+
+// maxGameAge is how long a game in progress is kept. Older games are considered abandoned and dropped.
+const maxGameAge = 24 * time.Hour
+
+// gameSessions keeps the games in progress, in memory, for every user and channel.
+type gameSessions struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*gameSession
+}
+
+func newGameSessions() *gameSessions {
+	return &gameSessions{
+		now:      time.Now,
+		sessions: make(map[string]*gameSession),
+	}
+}
+
+func gameSessionKey(kind string, channelId string, userId string) string {
+	return kind + ":" + channelId + ":" + userId
+}
+
+func (g *gameSessions) Get(kind string, channelId string, userId string) *gameSession {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	key := gameSessionKey(kind, channelId, userId)
+	session := g.sessions[key]
+	if session != nil && g.now().Sub(session.StartedAt) >= maxGameAge {
+		delete(g.sessions, key)
+		return nil
+	}
+	return session
+}
+
+// Set starts keeping a new game of a user, dropping the games abandoned by everyone.
+func (g *gameSessions) Set(userId string, session *gameSession) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for key, s := range g.sessions {
+		if now.Sub(s.StartedAt) >= maxGameAge {
+			delete(g.sessions, key)
+		}
+	}
+
+	session.StartedAt = now
+	g.sessions[gameSessionKey(session.Kind, session.ChannelId, userId)] = session
+}
+
+func (g *gameSessions) Delete(kind string, channelId string, userId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.sessions, gameSessionKey(kind, channelId, userId))
+}
+
+// channelToday returns the wordle day in the timezone of the channel, or in UTC if the channel is not tracked.
+func (b *WordleBot) channelToday(channelId string) int {
+	return wordle.DayForDate(time.Now().In(b.channelSettings(channelId).Location()))
+}
+
+func (b *WordleBot) HandlePlayInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	// Results are only recorded in tracked channels, so elsewhere the same wordle could be played over and over.
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	}
+
+	user := interactionUser(i)
+	day := b.channelToday(i.ChannelID)
+
+	session := b.games.Get(GameKindDaily, i.ChannelID, user.ID)
+	if session == nil || session.Day != day {
+		answer, ok := wordle.Answer(day)
+		if !ok {
+			return respondEphemeral(s, i, fmt.Sprintf("Sorry, I don't know the answer to Wordle %d yet.", day))
+		}
+
+		played, err := b.repository.HasAttempt(i.ChannelID, user.ID, day)
+		if err != nil {
+			return fmt.Errorf("checking attempt: %w", err)
+		}
+		if played {
+			return respondEphemeral(s, i, fmt.Sprintf("You already played Wordle %d in this channel.", day))
+		}
+
+		var hardMode bool
+		if opt, ok := subCommandOptions(i)["hard"]; ok {
+			hardMode = opt.BoolValue()
+		}
+
+		session = &gameSession{
+			Kind:      GameKindDaily,
+			ChannelId: i.ChannelID,
+			Day:       day,
+			Game: game.New(answer, game.Options{
+				HardMode:    hardMode,
+				IsValidWord: wordle.IsValidGuess,
+			}),
+		}
+		b.games.Set(user.ID, session)
+	}
+
+	return openGuessModal(s, i, session)
+}
+
+// openGuessModal responds to an interaction with a modal asking for the next guess of a game.
+func openGuessModal(s *discordgo.Session, i *discordgo.InteractionCreate, session *gameSession) error {
+	g := session.Game
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: guessModalPrefix + session.Kind,
+			Title:    fmt.Sprintf("%s — guess %d/%d", session.Title(), len(g.Guesses())+1, g.MaxGuesses()),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    guessInputId,
+							Label:       "Your guess",
+							Style:       discordgo.TextInputShort,
+							Placeholder: "A 5-letter word",
+							Required:    true,
+							MinLength:   game.WordLength,
+							MaxLength:   game.WordLength,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func (b *WordleBot) ModalSubmitHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionModalSubmit {
+		return
+	}
+
+	data := i.ModalSubmitData()
+
+	var err error
+	switch {
+	case strings.HasPrefix(data.CustomID, guessModalPrefix):
+		err = b.HandleGuessSubmit(s, i, strings.TrimPrefix(data.CustomID, guessModalPrefix))
+	}
+
+	if err != nil {
+		log.Errorf("responding to modal submit: %v\n", err)
+	}
+}
+
+// modalInputValue returns the value of a text input of a submitted modal.
+func modalInputValue(i *discordgo.InteractionCreate, customId string) string {
+	for _, row := range i.ModalSubmitData().Components {
+		actions, ok := row.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, c := range actions.Components {
+			if input, ok := c.(*discordgo.TextInput); ok && input.CustomID == customId {
+				return input.Value
+			}
+		}
+	}
+	return ""
+}
+
+// HandleGuessSubmit plays the guess entered in the modal of a game of the given kind.
+func (b *WordleBot) HandleGuessSubmit(s *discordgo.Session, i *discordgo.InteractionCreate, kind string) error {
+	user := interactionUser(i)
+	channelId := sessionChannel(kind, i.ChannelID)
+
+	session := b.games.Get(kind, channelId, user.ID)
+	if session == nil {
+		return respondEphemeral(s, i, "That game is no longer in progress, sorry :(")
+	}
+
+	g := session.Game
+	_, err := g.Guess(modalInputValue(i, guessInputId))
+	if err != nil {
+		return respondEphemeral(s, i, fmt.Sprintf("❌ %s.\n\n%s\nUse `%s` to try again.",
+			capitalize(err.Error()), gameBoard(g), continueCommand(session)))
+	}
+
+	if !g.Over() {
+		return respondEphemeral(s, i,
+			fmt.Sprintf("%s\nUse `%s` to make your next guess.", gameBoard(g), continueCommand(session)))
+	}
+
+	b.games.Delete(kind, channelId, user.ID)
+
+	content := fmt.Sprintf("🎉 You got it in %d!", len(g.Guesses()))
+	if !g.Won() {
+		content = fmt.Sprintf("💀 Out of guesses! The word was ||%s||.", g.Target())
+	}
+	err = respondEphemeral(s, i, content+"\n\n"+gameBoard(g))
+	if err != nil {
+		return fmt.Errorf("responding with game result: %w", err)
+	}
+
+	switch kind {
+	case GameKindChallenge:
+		return b.postChallengeResult(i, user, session)
+	case GameKindPractice:
+		return b.savePracticeResult(user, session)
+	default:
+		return b.postPlayedResult(i, user, session)
+	}
+}
+
+// continueCommand is the command a player uses to make the next guess of a game.
+func continueCommand(session *gameSession) string {
+	switch session.Kind {
+	case GameKindChallenge:
+		return fmt.Sprintf("/wordle challenge solve id:%d", session.Challenge.Id)
+	case GameKindPractice:
+		return "/wordle practice"
+	default:
+		return "/wordle play"
+	}
+}
+
+// postPlayedResult posts the share text of a finished daily game in the channel and records it as a copy/paste of
+// the player.
+func (b *WordleBot) postPlayedResult(i *discordgo.InteractionCreate, user *discordgo.User, session *gameSession) error {
+	msg, err := b.postForPlayer(i.ChannelID, user, &discordgo.MessageSend{
+		Content: fmt.Sprintf("<@%s> played in discord:\n%s",
+			user.ID, session.Game.Share(session.Title())),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		return fmt.Errorf("posting result: %w", err)
+	}
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return nil
+	}
+
+	b.recordLiveMessage(msg, b.channelSettings(i.ChannelID))
+
+	return nil
+}
+
+// gameBoard shows the guesses of a game with their results, for the player only.
+func gameBoard(g *game.Game) string {
+	var builder strings.Builder
+
+	grid := strings.Split(g.Grid(), "\n")
+	for n, guess := range g.Guesses() {
+		fmt.Fprintf(&builder, "%s `%s`\n", grid[n], guess)
+	}
+
+	if absent := g.AbsentLetters(); len(absent) > 0 && !g.Over() {
+		fmt.Fprintf(&builder, "Not in the word: %s\n", strings.Join(strings.Split(string(absent), ""), " "))
+	}
+
+	return builder.String()
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) error {
+	return s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   uint64(discordgo.MessageFlagsEphemeral),
+		},
+	})
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	return strings.ToUpper(string(r[0])) + string(r[1:])
+}
@@ -0,0 +1,33 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGameSessionsDropAbandonedGames(t *testing.T) {
+	now := time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC)
+	games := newGameSessions()
+	games.now = func() time.Time { return now }
+
+	games.Set("alice", &gameSession{Kind: GameKindDaily, ChannelId: "c", Day: 450})
+	if games.Get(GameKindDaily, "c", "alice") == nil {
+		t.Fatalf("Get() of a game just started = nil, want the game")
+	}
+
+	now = now.Add(maxGameAge - time.Minute)
+	games.Set("bob", &gameSession{Kind: GameKindPractice})
+	if games.Get(GameKindDaily, "c", "alice") == nil {
+		t.Errorf("Get() of a game started less than a day ago = nil, want the game")
+	}
+
+	now = now.Add(time.Minute)
+	if games.Get(GameKindDaily, "c", "alice") != nil {
+		t.Errorf("Get() of a game started a day ago returned it, want it dropped")
+	}
+
+	games.Set("carol", &gameSession{Kind: GameKindPractice})
+	if len(games.sessions) != 2 {
+		t.Errorf("%d games kept, want only the games of bob and carol", len(games.sessions))
+	}
+}
@@ -0,0 +1,151 @@
+package wordlebot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// channelGuild returns the guild of a channel, for messages that don't say which guild they were posted in, like the
+// ones fetched from the history of a channel. Empty if it isn't known.
+func (b *WordleBot) channelGuild(channelId string) string {
+	if channel, err := b.session.State.Channel(channelId); err == nil {
+		return channel.GuildID
+	}
+
+	channel, err := b.repository.TrackedChannel(channelId)
+	if err != nil {
+		log.Errorf("getting guild of channel %s: %v\n", channelId, err)
+		return ""
+	}
+	if channel == nil {
+		return ""
+	}
+
+	return channel.GuildId
+}
+
+// backfillGuilds stores the guild of the channels tracked before guild ids were stored, and of their attempts.
+// Channels the bot can no longer see are left for the next start.
+func (b *WordleBot) backfillGuilds() {
+	channels, err := b.repository.TrackedChannels()
+	if err != nil {
+		log.Errorf("getting tracked channels to backfill their guilds: %v\n", err)
+		return
+	}
+
+	for _, tracked := range channels {
+		if tracked.GuildId != "" {
+			continue
+		}
+
+		channel, err := b.session.Channel(tracked.ChannelId)
+		if err != nil {
+			log.Errorf("getting channel %s to backfill its guild: %v\n", tracked.ChannelId, err)
+			continue
+		}
+		if channel.GuildID == "" {
+			continue
+		}
+
+		err = b.repository.SetChannelGuild(tracked.ChannelId, channel.GuildID)
+		if err != nil {
+			log.Errorf("backfilling guild of channel %s: %v\n", tracked.ChannelId, err)
+			continue
+		}
+
+		log.Infof("Backfilled guild %s of channel %s\n", channel.GuildID, tracked.ChannelId)
+	}
+}
+
+// memberRolesTTL is how long the roles of the members of a guild are kept before they are loaded again.
+const memberRolesTTL = 5 * time.Minute
+
+// membersPageSize is the most members discord returns in a page of the members of a guild.
+const membersPageSize = 1000
+
+// memberRolesCache keeps the roles of the members of each guild in memory for a while, so ranking teams doesn't
+// list the members of a guild every time.
+type memberRolesCache struct {
+	load func(guildId string) (map[string][]string, error)
+	now  func() time.Time
+
+	mu     sync.Mutex
+	guilds map[string]cachedMemberRoles
+}
+
+type cachedMemberRoles struct {
+	roles    map[string][]string
+	loadedAt time.Time
+}
+
+func newMemberRolesCache(load func(guildId string) (map[string][]string, error)) *memberRolesCache {
+	return &memberRolesCache{
+		load:   load,
+		now:    time.Now,
+		guilds: make(map[string]cachedMemberRoles),
+	}
+}
+
+// Get returns the roles of the members of a guild, by user id.
+func (c *memberRolesCache) Get(guildId string) (map[string][]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.guilds[guildId]; ok && c.now().Sub(cached.loadedAt) < memberRolesTTL {
+		return cached.roles, nil
+	}
+
+	roles, err := c.load(guildId)
+	if err != nil {
+		return nil, fmt.Errorf("loading member roles of guild %s: %w", guildId, err)
+	}
+
+	c.guilds[guildId] = cachedMemberRoles{roles: roles, loadedAt: c.now()}
+	return roles, nil
+}
+
+// memberRoles returns the roles of the members of a guild, by user id.
+func (b *WordleBot) memberRoles(guildId string) (map[string][]string, error) {
+	return b.roles.Get(guildId)
+}
+
+// listMemberRoles lists the members of a guild, a page at a time, and returns their roles by user id. Listing members
+// needs the server members intent to be enabled for the bot.
+func (b *WordleBot) listMemberRoles(guildId string) (map[string][]string, error) {
+	roles := make(map[string][]string)
+	after := ""
+	for {
+		members, err := b.session.GuildMembers(guildId, after, membersPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("getting members of guild %s: %w", guildId, err)
+		}
+
+		for _, m := range members {
+			if m.User != nil {
+				roles[m.User.ID] = m.Roles
+			}
+		}
+
+		if len(members) < membersPageSize || members[len(members)-1].User == nil {
+			return roles, nil
+		}
+		after = members[len(members)-1].User.ID
+	}
+}
+
+// roleNames returns the current names of the roles of a guild, by role id.
+func (b *WordleBot) roleNames(guildId string) (map[string]string, error) {
+	roles, err := b.session.GuildRoles(guildId)
+	if err != nil {
+		return nil, fmt.Errorf("getting roles of guild %s: %w", guildId, err)
+	}
+
+	names := make(map[string]string, len(roles))
+	for _, r := range roles {
+		names[r.ID] = r.Name
+	}
+	return names, nil
+}
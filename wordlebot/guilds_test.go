@@ -0,0 +1,42 @@
+package wordlebot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemberRolesCache(t *testing.T) {
+	stored := map[string][]string{"1": {"r"}}
+	loads := 0
+	cache := newMemberRolesCache(func(guildId string) (map[string][]string, error) {
+		loads++
+		if guildId == "broken" {
+			return nil, errors.New("missing access")
+		}
+		return stored, nil
+	})
+	now := time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for n := 0; n < 2; n++ {
+		roles, err := cache.Get("g")
+		if err != nil || len(roles["1"]) != 1 {
+			t.Fatalf("Get() = %v, %v, want the roles of member 1", roles, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("roles loaded %d times, want once", loads)
+	}
+
+	stored = map[string][]string{"1": {"r", "b"}}
+	now = now.Add(memberRolesTTL)
+	roles, err := cache.Get("g")
+	if err != nil || len(roles["1"]) != 2 {
+		t.Errorf("Get() after the roles expired = %v, %v, want the new roles of member 1", roles, err)
+	}
+
+	if _, err := cache.Get("broken"); err == nil {
+		t.Errorf("Get() of a guild whose members can't be listed returned no error")
+	}
+}
@@ -0,0 +1,120 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+func (b *WordleBot) HandleHelpInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		log.Errorf("checking tracked channel %s for help: %v\n", i.ChannelID, err)
+	}
+
+	var settings *channelSettings
+	if tracked {
+		c := b.channelSettings(i.ChannelID)
+		settings = &c
+	}
+
+	return respondEphemeralEmbed(s, i, helpEmbed(wordleCommand(), contextMenuCommands(), settings))
+}
+
+// helpEmbed describes the commands of the bot, from their definitions, and the settings of a channel, which are nil
+// if the channel isn't tracked.
+func helpEmbed(command *discordgo.ApplicationCommand, menuCommands []*discordgo.ApplicationCommand,
+	settings *channelSettings) *discordgo.MessageEmbed {
+	embed := newEmbed("Wordle bot help", commandReference(command), colorInfo)
+
+	scoring := newChannelSettings(nil)
+	if settings != nil {
+		scoring = *settings
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{Name: "Menus", Value: menuReference(menuCommands)},
+		{Name: "This channel", Value: channelStatus(settings)},
+		{Name: "Scoring", Value: fmt.Sprintf("The leaderboard adds up the scores of the last %d days. %s.",
+			scoring.LeaderboardDays, scoringRule(scoring.Scoring, scoring.LeaderboardDays))},
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: fmt.Sprintf("Commands that only show information also take a \"%s\" option to choose who sees the "+
+			"response.", publicOption.Name),
+	}
+
+	return embed
+}
+
+// commandReference lists the subcommands of a chat command with their options, one per line. Required options
+// are in angle brackets and optional ones in square brackets.
+func commandReference(command *discordgo.ApplicationCommand) string {
+	var builder strings.Builder
+
+	var list func(prefix string, options []*discordgo.ApplicationCommandOption)
+	list = func(prefix string, options []*discordgo.ApplicationCommandOption) {
+		for _, opt := range options {
+			switch opt.Type {
+			case discordgo.ApplicationCommandOptionSubCommandGroup:
+				list(prefix+" "+opt.Name, opt.Options)
+			case discordgo.ApplicationCommandOptionSubCommand:
+				fmt.Fprintf(&builder, "`%s %s%s` %s\n", prefix, opt.Name, optionsUsage(opt.Options), opt.Description)
+			}
+		}
+	}
+	list("/"+command.Name, command.Options)
+
+	return builder.String()
+}
+
+func optionsUsage(options []*discordgo.ApplicationCommandOption) string {
+	var usage string
+	for _, opt := range options {
+		switch {
+		case opt == publicOption:
+			continue
+		case opt.Required:
+			usage += fmt.Sprintf(" <%s>", opt.Name)
+		default:
+			usage += fmt.Sprintf(" [%s]", opt.Name)
+		}
+	}
+	return usage
+}
+
+// menuReference explains where to find the context menu commands.
+func menuReference(commands []*discordgo.ApplicationCommand) string {
+	var builder strings.Builder
+	for _, c := range commands {
+		switch c.Type {
+		case discordgo.UserApplicationCommand:
+			fmt.Fprintf(&builder, "Right-click a member › Apps › **%s**\n", c.Name)
+		case discordgo.MessageApplicationCommand:
+			fmt.Fprintf(&builder, "Right-click a message › Apps › **%s**\n", c.Name)
+		}
+	}
+	return builder.String()
+}
+
+// channelStatus describes whether a channel is tracked and how it's set up.
+func channelStatus(settings *channelSettings) string {
+	if settings == nil {
+		return "Not tracked. Use `/wordle track` to start recording the wordles posted here."
+	}
+
+	summary := "off"
+	if settings.SummaryTime != "" {
+		summary = fmt.Sprintf("%s (%s)", settings.SummaryTime, settings.Timezone)
+	}
+
+	responses := "only to the member who asks"
+	if settings.PublicResponses {
+		responses = "to everyone"
+	}
+
+	return fmt.Sprintf("Tracked ✅\nReactions: `%s` · Spoiler guard: `%s` · Daily summary: %s\nResponses shown %s\n"+
+		"See all the settings with `/wordle config get`.", settings.ReactionSet, settings.SpoilerGuard, summary,
+		responses)
+}
@@ -0,0 +1,42 @@
+package wordlebot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandReference(t *testing.T) {
+	reference := commandReference(wordleCommand())
+
+	for _, want := range []string{
+		"`/wordle help` ",
+		"`/wordle answer <day>` ",
+		"`/wordle chart <type> [user] [format]` ",
+		"`/wordle remind on [time] [timezone] [via]` ",
+		"`/wordle challenge solve <id> [hard]` ",
+	} {
+		if !strings.Contains(reference, want) {
+			t.Errorf("commandReference() doesn't contain %q:\n%s", want, reference)
+		}
+	}
+
+	if strings.Contains(reference, "[public]") {
+		t.Errorf("commandReference() lists the public option:\n%s", reference)
+	}
+}
+
+func TestHelpEmbedFitsDiscordLimits(t *testing.T) {
+	settings := newChannelSettings(nil)
+	embed := helpEmbed(wordleCommand(), contextMenuCommands(), &settings)
+
+	if len(embed.Description) > 4096 {
+		t.Errorf("helpEmbed() description has %d characters, more than the 4096 discord allows",
+			len(embed.Description))
+	}
+	for _, field := range embed.Fields {
+		if len(field.Value) > 1024 {
+			t.Errorf("helpEmbed() field %q has %d characters, more than the 1024 discord allows", field.Name,
+				len(field.Value))
+		}
+	}
+}
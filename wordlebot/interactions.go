@@ -2,103 +2,441 @@ package wordlebot
 
 import (
 	"fmt"
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
 	"github.com/bwmarrin/discordgo"
 	log "github.com/sirupsen/logrus"
+	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 )
 
 func (b *WordleBot) HandleTrackInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
 	ok, _ := b.repository.IsTrackedChannel(i.ChannelID)
 	var err error
 	if ok {
-		err = b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "This channel is already being tracked.",
-			},
-		})
-		return err
+		return respondEmbed(s, i, newEmbed("", "This channel is already being tracked.", colorNeutral))
 	}
 
-	message := "The wordle bot is now tracking this channel for wordle messages. " +
-		"Past messages will also be scanned for wordle copy/pastes."
-	err = b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: message,
-		},
-	})
+	err = b.repository.TrackChannel(i.ChannelID, i.GuildID)
 	if err != nil {
-		return fmt.Errorf("responding to initial interaction: %w", err)
+		return fmt.Errorf("tracking channel: %w", err)
 	}
 
-	err = b.repository.TrackChannel(i.ChannelID)
+	job, _, err := b.jobs.Enqueue(db.JobKindChannelScan, i.ChannelID)
 	if err != nil {
-		return fmt.Errorf("tracking channel: %w", err)
+		return fmt.Errorf("queueing channel scan: %w", err)
 	}
 
-	procResult, err := b.ProcessChannelMessages(i.ChannelID)
-	if err != nil {
-		return fmt.Errorf("processing channel messages: %w", err)
+	scan := "Past messages will also be scanned for wordle copy/pastes. "
+	if job != nil {
+		scan = fmt.Sprintf("Past messages will also be scanned for wordle copy/pastes (job #%d). ", job.Id)
 	}
 
-	_, err = b.session.InteractionResponseEdit(
-		i.Interaction,
-		&discordgo.WebhookEdit{
-			Content: message + "\n" +
-				fmt.Sprintf("📩 Import of old messages is now completed! %d messages were processed "+
-					"of which %d were wordle copy/pastes", procResult.TotalMessages, procResult.WordleMessages),
-		},
-	)
+	err = respondEmbed(s, i, newEmbed("Tracking this channel",
+		"The wordle bot is now tracking this channel for wordle messages. "+scan+
+			"Use `/wordle jobs` to check on its progress.", colorSuccess))
 	if err != nil {
-		return fmt.Errorf("updating interaction: %w", err)
+		return fmt.Errorf("responding to initial interaction: %w", err)
 	}
+
 	return nil
 }
 
-func (b *WordleBot) HandleLeaderboardInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+func (b *WordleBot) HandleJobsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	jobs, err := b.repository.RecentJobs(i.ChannelID, 5)
+	if err != nil {
+		log.Errorf("Error handling jobs interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	if len(jobs) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", "There are no jobs for this channel.", colorNeutral), flags)
+	}
+
 	var builder strings.Builder
+	for _, job := range jobs {
+		builder.WriteString(formatJob(job) + "\n")
+	}
 
-	tabw := tabwriter.NewWriter(&builder, 2, 2, 2, ' ', tabwriter.TabIndent)
+	return respondEmbedWithFlags(s, i, newEmbed("Recent jobs", builder.String(), colorInfo), flags)
+}
 
-	fmt.Fprintf(tabw, "Name\tScore\tAvg. Attempts\tGames Played\n")
-	entries, err := b.repository.Leaderboard(i.ChannelID)
-	if err != nil {
-		log.Errorf("Error handling leaderboard interaction: %v", err)
+var minDay = 0.0
 
-		b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "There was a problem processing this request, sorry :(",
-			},
-		})
+// lastFinishedDay returns the last day whose wordle is over in every timezone.
+func lastFinishedDay(now time.Time) int {
+	return wordle.DayForDate(now.In(time.FixedZone("UTC-12", -12*60*60))) - 1
+}
+
+// latestStartedDay returns the latest day whose wordle has started somewhere, in the earliest timezone.
+func latestStartedDay(now time.Time) int {
+	return wordle.DayForDate(now.In(time.FixedZone("UTC+14", 14*60*60)))
+}
+
+func (b *WordleBot) HandleAnswerInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	day := int(subCommandOptions(i)["day"].IntValue())
+
+	answer, known := wordle.Answer(day)
+
+	var embed *discordgo.MessageEmbed
+	switch {
+	case day > lastFinishedDay(time.Now()):
+		embed = newEmbed("", fmt.Sprintf("Wordle %d is still being played somewhere in the world, no spoilers!", day),
+			colorWarning)
+	case !known:
+		embed = newEmbed("", fmt.Sprintf("Sorry, I don't know the answer to Wordle %d.", day), colorNeutral)
+	default:
+		embed = newEmbed(fmt.Sprintf("Wordle %d", day), fmt.Sprintf("The answer was ||%s||.", strings.ToUpper(answer)),
+			colorSuccess)
+	}
+
+	return respondEmbedWithFlags(s, i, embed, flags)
+}
+
+const (
+	defaultRegularMinDays = 3
+	defaultRegularDays    = 7
+	maxRegularDays        = 90
+)
+
+var minRegularDays = 1.0
+
+func (b *WordleBot) HandleMissingInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	options := subCommandOptions(i)
+
+	minDays, days := defaultRegularMinDays, defaultRegularDays
+	if opt, ok := options["min_days"]; ok {
+		minDays = int(opt.IntValue())
+	}
+	if opt, ok := options["days"]; ok {
+		days = int(opt.IntValue())
+	}
 
+	regulars, err := b.repository.MissingRegulars(i.ChannelID, minDays, days)
+	if err != nil {
+		log.Errorf("Error handling missing interaction: %v", err)
+		respondProblem(s, i, flags)
 		return err
 	}
 
-	for _, entry := range entries {
-		fmt.Fprintf(tabw, "%s\t%.2f\t%.2f\t%d\n",
-			entry.Username, entry.TotalScore, entry.AvgAttempts, entry.Played)
+	if len(regulars) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", fmt.Sprintf("Everyone who posted in at least %d of the "+
+			"last %d days already posted today 🎉", minDays, days), colorSuccess), flags)
+	}
+
+	var builder strings.Builder
+	for _, r := range regulars {
+		fmt.Fprintf(&builder, "• <@%s> (%d/%d days)\n", r.UserId, r.Played, days)
+	}
+
+	embed := newEmbed("Still missing today", builder.String(), colorWarning)
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: fmt.Sprintf("Regulars posted in at least %d of the last %d days", minDays, days),
+	}
+
+	return respondEmbedWithFlags(s, i, embed, flags)
+}
+
+func (b *WordleBot) HandleReactionsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	reactionSet := subCommandOptions(i)["set"].StringValue()
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEmbed(s, i, notTrackedEmbed())
+	}
+
+	if !sliceHasString(reactionSets, reactionSet) {
+		return fmt.Errorf("unknown reaction set %q", reactionSet)
+	}
+
+	err = b.setChannelSettings(i.ChannelID, map[string]string{settingReactions: reactionSet})
+	if err != nil {
+		return fmt.Errorf("setting reaction set: %w", err)
+	}
+
+	return respondEmbed(s, i, newEmbed("", fmt.Sprintf("Wordle copy/pastes in this channel will now use the `%s` "+
+		"reactions.", reactionSet), colorSuccess))
+}
+
+func (b *WordleBot) HandleSpoilersInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	mode := subCommandOptions(i)["mode"].StringValue()
+	if !sliceHasString(spoilerGuardModes, mode) {
+		return fmt.Errorf("unknown spoiler guard mode %q", mode)
+	}
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+
+	if !tracked {
+		return respondEmbed(s, i, notTrackedEmbed())
+	}
+
+	err = b.setChannelSettings(i.ChannelID, map[string]string{settingSpoilerGuard: mode})
+	if err != nil {
+		return fmt.Errorf("setting spoiler guard: %w", err)
+	}
+
+	var content string
+	switch mode {
+	case SpoilerGuardOff:
+		content = "The spoiler guard is now off for this channel."
+	case SpoilerGuardDelete:
+		content = "Messages revealing today's answer will be deleted."
+	case SpoilerGuardSpoiler:
+		content = "Messages revealing today's answer will be posted again with the answer in spoiler tags."
+	case SpoilerGuardWarn:
+		content = "Authors of messages revealing today's answer will be asked to hide it."
 	}
 
-	tabw.Flush()
-	table := builder.String()
+	return respondEmbed(s, i, newEmbed("", content, colorSuccess))
+}
+
+func (b *WordleBot) HandleSummaryInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	options := subCommandOptions(i)
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEmbed(s, i, notTrackedEmbed())
+	}
+
+	values := map[string]string{settingSummaryTime: options["time"].StringValue()}
+	if opt, ok := options["timezone"]; ok {
+		values[settingTimezone] = opt.StringValue()
+	}
+
+	values, err = normalizeSettings(values)
+	if err != nil {
+		return respondEmbed(s, i, newEmbed("", err.Error(), colorFailure))
+	}
+
+	err = b.setChannelSettings(i.ChannelID, values)
+	if err != nil {
+		return fmt.Errorf("setting summary schedule: %w", err)
+	}
+
+	settings := b.channelSettings(i.ChannelID)
+	content := "Daily summaries are now disabled for this channel."
+	if settings.SummaryTime != "" {
+		content = fmt.Sprintf("A summary of yesterday's results will be posted here every day at %s (%s).",
+			settings.SummaryTime, settings.Timezone)
+	}
 
-	err = b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Content: "Here's the current leaderboard:\n" +
-				"```\n" + table + "\n```",
-		},
+	return respondEmbed(s, i, newEmbed("", content, colorSuccess))
+}
+
+func (b *WordleBot) HandleRecapInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	options := subCommandOptions(i)
+	weekly, monthly := options["weekly"].BoolValue(), options["monthly"].BoolValue()
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+
+	if !tracked {
+		return respondEmbed(s, i, notTrackedEmbed())
+	}
+
+	err = b.setChannelSettings(i.ChannelID, map[string]string{
+		settingWeeklyRecap:  strconv.FormatBool(weekly),
+		settingMonthlyRecap: strconv.FormatBool(monthly),
 	})
+	if err != nil {
+		return fmt.Errorf("setting recaps: %w", err)
+	}
+
+	var enabled []string
+	if weekly {
+		enabled = append(enabled, "weekly")
+	}
+	if monthly {
+		enabled = append(enabled, "monthly")
+	}
+
+	content := "Recaps are now disabled for this channel."
+	if len(enabled) > 0 {
+		content = fmt.Sprintf("The %s recaps will be posted in this channel at the daily summary time (%s if unset).",
+			strings.Join(enabled, " and "), defaultRecapTime)
+	}
+
+	return respondEmbed(s, i, newEmbed("", content, colorSuccess))
+}
+
+func (b *WordleBot) HandleRemindInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+
+	var content string
+	color := colorSuccess
+	switch {
+	case !tracked:
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	case subCommandGroupCommand(i) == "off":
+		deleted, err := b.repository.DeleteReminder(i.ChannelID, user.ID)
+		if err != nil {
+			return fmt.Errorf("deleting reminder: %w", err)
+		}
+
+		content, color = "You didn't have a reminder for this channel.", colorNeutral
+		if deleted {
+			content, color = "You will no longer be reminded to post in this channel.", colorSuccess
+		}
+	default:
+		options := subCommandOptions(i)
+		reminder := db.Reminder{
+			ChannelId: i.ChannelID,
+			UserId:    user.ID,
+			RemindAt:  defaultReminderTime,
+			Timezone:  b.channelSettings(i.ChannelID).Timezone,
+			Delivery:  db.ReminderDeliveryDM,
+		}
+		if opt, ok := options["time"]; ok {
+			reminder.RemindAt = strings.TrimSpace(opt.StringValue())
+		}
+		if opt, ok := options["timezone"]; ok {
+			reminder.Timezone = strings.TrimSpace(opt.StringValue())
+		}
+		if opt, ok := options["via"]; ok {
+			reminder.Delivery = opt.StringValue()
+		}
+
+		_, _, validTime := parseClock(reminder.RemindAt)
+		_, tzErr := time.LoadLocation(reminder.Timezone)
+
+		switch {
+		case !validTime:
+			content = fmt.Sprintf("%q is not a valid time. Use the HH:MM format, e.g. `20:30`.", reminder.RemindAt)
+			color = colorFailure
+		case reminder.Timezone == "" || tzErr != nil:
+			content = fmt.Sprintf("%q is not a valid timezone. Use a name like `Europe/Lisbon` or `America/New_York`.",
+				reminder.Timezone)
+			color = colorFailure
+		default:
+			err = b.repository.SaveReminder(reminder)
+			if err != nil {
+				return fmt.Errorf("saving reminder: %w", err)
+			}
 
-	return err
+			content = fmt.Sprintf("You will be reminded at %s (%s) if you haven't posted the wordle of the day "+
+				"in this channel by then.", reminder.RemindAt, reminder.Timezone)
+		}
+	}
+
+	return respondEphemeralEmbed(s, i, newEmbed("", content, color))
+}
+
+func formatJob(job db.Job) string {
+	var status string
+	switch job.Status {
+	case db.JobStatusQueued:
+		status = "⏳ queued"
+	case db.JobStatusRunning:
+		status = fmt.Sprintf("🔄 running since <t:%d:R>", job.StartedAt.Unix())
+	case db.JobStatusDone:
+		status = fmt.Sprintf("✅ finished <t:%d:R>", job.FinishedAt.Unix())
+	case db.JobStatusFailed:
+		status = fmt.Sprintf("❌ failed <t:%d:R>: %s", job.FinishedAt.Unix(), job.Error)
+	default:
+		status = job.Status
+	}
+
+	return fmt.Sprintf("`#%d` %s — %s, %d messages processed, %d wordle copy/pastes",
+		job.Id, job.Kind, status, job.TotalMessages, job.WordleMessages)
 }
 
 func (b *WordleBot) HandleDayInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
-	err := b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponsePong,
-	})
-	return err
+	flags := b.responseFlags(i)
+
+	day := int(subCommandOptions(i)["day"].IntValue())
+
+	if day > latestStartedDay(time.Now()) {
+		return respondEmbedWithFlags(s, i, newEmbed("", fmt.Sprintf("Wordle %d hasn't started yet.", day),
+			colorNeutral), flags)
+	}
+
+	attempts, err := b.repository.AttemptsForDay(i.ChannelID, day)
+	if err != nil {
+		log.Errorf("Error handling day interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	return respondEmbedWithFlags(s, i, dayEmbed(day, attempts, b.channelSettings(i.ChannelID)), flags)
+}
+
+// dayEmbed shows the results of a day, with attempts sorted from best to worst, scored with the settings of the
+// channel.
+func dayEmbed(day int, attempts []db.Attempt, settings channelSettings) *discordgo.MessageEmbed {
+	title := fmt.Sprintf("Wordle %d", day)
+	footer := scoringFooter(wordle.DateForDay(day).Format("Monday, 2 January 2006"), settings)
+
+	if len(attempts) == 0 {
+		embed := newEmbed(title, "Nobody in this channel posted this wordle.", colorNeutral)
+		embed.Footer = footer
+		return embed
+	}
+
+	var builder strings.Builder
+	var solved, guesses int
+	for _, a := range attempts {
+		result := "X"
+		if a.Success {
+			result = fmt.Sprint(a.Attempts)
+			solved++
+			guesses += a.Attempts
+		}
+		fmt.Fprintf(&builder, "`%s/%d%s` %s · %.0f points\n", result, a.MaxAttempts, hardModeMark(a.HardMode),
+			a.UserName, attemptScore(settings.Scoring, a))
+	}
+
+	avg := "-"
+	if solved > 0 {
+		avg = fmt.Sprintf("%.2f", float64(guesses)/float64(solved))
+	}
+
+	embed := newEmbed(title, builder.String(), rateColor(solved, len(attempts)))
+	embed.Fields = []*discordgo.MessageEmbedField{
+		statField("Played", len(attempts)),
+		statField("Solved", fmt.Sprintf("%d (%d%%)", solved, winRate(solved, len(attempts)))),
+		statField("Avg. guesses", avg),
+	}
+	embed.Footer = footer
+
+	return embed
 }
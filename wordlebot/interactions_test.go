@@ -0,0 +1,30 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+func TestLatestStartedDay(t *testing.T) {
+	now := time.Date(2022, 9, 14, 9, 0, 0, 0, time.UTC)
+	today := wordle.DayForDate(now)
+
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{now, today},
+		{now.Add(time.Hour), today + 1},
+		// The timezone of the time doesn't matter, only the instant.
+		{now.In(time.FixedZone("UTC-10", -10*60*60)), today},
+		{now.Add(time.Hour).In(time.FixedZone("UTC-10", -10*60*60)), today + 1},
+	}
+
+	for _, test := range tests {
+		if got := latestStartedDay(test.now); got != test.want {
+			t.Errorf("latestStartedDay(%v) = %d, want %d", test.now, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package wordlebot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultJobWorkers = 2
+	jobPollInterval   = 30 * time.Second
+)
+
+// jobQueue runs background jobs persisted in the database with a bounded pool of workers.
+// Jobs are claimed from the database, so they survive restarts of the bot.
+type jobQueue struct {
+	bot     *WordleBot
+	workers int
+	wake    chan struct{}
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func newJobQueue(bot *WordleBot, workers int) *jobQueue {
+	if workers <= 0 {
+		workers = defaultJobWorkers
+	}
+
+	return &jobQueue{
+		bot:     bot,
+		workers: workers,
+		wake:    make(chan struct{}, workers),
+	}
+}
+
+// Start requeues jobs interrupted by a previous shutdown and starts the workers.
+func (q *jobQueue) Start() error {
+	requeued, err := q.bot.repository.RequeueRunningJobs()
+	if err != nil {
+		return fmt.Errorf("requeueing interrupted jobs: %w", err)
+	}
+	if requeued > 0 {
+		log.Infof("requeued %d interrupted jobs", requeued)
+	}
+
+	var ctx context.Context
+	ctx, q.cancel = context.WithCancel(context.Background())
+
+	for w := 0; w < q.workers; w++ {
+		q.wg.Add(1)
+		go q.work(ctx)
+	}
+
+	return nil
+}
+
+// Stop signals the workers to stop and waits for them to finish.
+// Jobs interrupted by Stop are resumed on the next Start.
+func (q *jobQueue) Stop() {
+	if q.cancel == nil {
+		return
+	}
+	q.cancel()
+	q.wg.Wait()
+}
+
+// Enqueue persists a new job for a channel and wakes up an idle worker. If the channel already has an active job
+// of the same kind, the existing job is returned and created is false.
+func (q *jobQueue) Enqueue(kind string, channelId string) (job *db.Job, created bool, err error) {
+	job, created, err = q.bot.repository.EnqueueJob(kind, channelId)
+	if err != nil {
+		return nil, false, fmt.Errorf("enqueueing job: %w", err)
+	}
+
+	if created {
+		select {
+		case q.wake <- struct{}{}:
+		default:
+		}
+	}
+
+	return job, created, nil
+}
+
+func (q *jobQueue) work(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for q.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and runs the next queued job. Returns false if there was nothing to run.
+func (q *jobQueue) runNext(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	job, err := q.bot.repository.ClaimNextJob()
+	if err != nil {
+		log.Errorf("claiming next job: %v\n", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	var jobErr error
+	switch job.Kind {
+	case db.JobKindChannelScan:
+		jobErr = q.bot.runChannelScanJob(ctx, job)
+	default:
+		jobErr = fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+
+	if ctx.Err() != nil {
+		// The bot is shutting down, leave the job as running so it is requeued on the next start.
+		return false
+	}
+
+	if jobErr != nil {
+		log.Errorf("running job %d (%s) for channel %s: %v\n", job.Id, job.Kind, job.ChannelId, jobErr)
+	}
+
+	err = q.bot.repository.FinishJob(job.Id, jobErr)
+	if err != nil {
+		log.Errorf("finishing job %d: %v\n", job.Id, err)
+	}
+
+	return true
+}
+
+func (b *WordleBot) runChannelScanJob(ctx context.Context, job *db.Job) error {
+	procResult, err := b.ProcessChannelMessages(ctx, job.ChannelId, func(progress ProcessResult) {
+		err := b.repository.UpdateJobProgress(job.Id, progress.TotalMessages, progress.WordleMessages)
+		if err != nil {
+			log.Errorf("updating progress of job %d: %v\n", job.Id, err)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("processing channel messages: %w", err)
+	}
+
+	_, err = b.session.ChannelMessageSend(job.ChannelId,
+		fmt.Sprintf("📩 Import of old messages is now completed! %d messages were processed "+
+			"of which %d were wordle copy/pastes", procResult.TotalMessages, procResult.WordleMessages))
+	if err != nil {
+		log.Errorf("announcing completion of job %d: %v\n", job.Id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package wordlebot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// leaderboardPageSize is the number of players in each page of the leaderboard.
+	leaderboardPageSize = 10
+	// leaderboardButtonPrefix starts the custom id of the leaderboard page buttons, followed by the page and the id
+	// of the user whose rank is highlighted, e.g. "leaderboard:2:1234".
+	leaderboardButtonPrefix = "leaderboard:"
+	leaderboardImageName    = "leaderboard.png"
+)
+
+// Scopes of the leaderboard.
+const (
+	// leaderboardScopeChannel counts the results of the current channel.
+	leaderboardScopeChannel = "channel"
+	// leaderboardScopeServer counts the results of every tracked channel of the server, once per player and day.
+	leaderboardScopeServer = "server"
+)
+
+func (b *WordleBot) HandleLeaderboardInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	var guildId string
+	if opt, ok := subCommandOptions(i)["scope"]; ok && opt.StringValue() == leaderboardScopeServer {
+		if i.GuildID == "" {
+			return respondEphemeralEmbed(s, i, newEmbed("", "Server leaderboards are only available in servers.",
+				colorWarning))
+		}
+		guildId = i.GuildID
+	}
+
+	data, files, err := b.leaderboardPage(i.ChannelID, guildId, 0, interactionUser(i).ID)
+	if err != nil {
+		log.Errorf("Error handling leaderboard interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	data.Files = files
+	data.Flags = flags
+	return b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: data,
+	})
+}
+
+// HandleLeaderboardButton shows the page of the leaderboard of a button pressed in a leaderboard message.
+func (b *WordleBot) HandleLeaderboardButton(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	page, userId, server, ok := parseLeaderboardButton(i.MessageComponentData().CustomID)
+	if !ok {
+		return fmt.Errorf("invalid leaderboard button %q", i.MessageComponentData().CustomID)
+	}
+
+	var guildId string
+	if server {
+		guildId = i.GuildID
+	}
+
+	data, files, err := b.leaderboardPage(i.ChannelID, guildId, page, userId)
+	if err != nil {
+		return fmt.Errorf("building leaderboard page: %w", err)
+	}
+
+	return b.respondReplacingFiles(i, discordgo.InteractionResponseUpdateMessage, data, files)
+}
+
+// leaderboardButtonId is the custom id of a leaderboard page button. Buttons of server leaderboards end in ":server".
+func leaderboardButtonId(page int, userId string, server bool) string {
+	id := fmt.Sprintf("%s%d:%s", leaderboardButtonPrefix, page, userId)
+	if server {
+		id += ":" + leaderboardScopeServer
+	}
+	return id
+}
+
+func parseLeaderboardButton(customId string) (page int, userId string, server bool, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(customId, leaderboardButtonPrefix), ":")
+	if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != leaderboardScopeServer) {
+		return 0, "", false, false
+	}
+
+	page, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", false, false
+	}
+
+	return page, parts[1], len(parts) == 3, true
+}
+
+// leaderboardPage builds the message with a page of the leaderboard of a channel, or of its guild if guildId is set,
+// with the rank of the given user always shown, and the files attached to it.
+func (b *WordleBot) leaderboardPage(channelId string, guildId string, page int,
+	userId string) (*discordgo.InteractionResponseData, []*discordgo.File, error) {
+	settings := b.channelSettings(channelId)
+	today := wordle.DayForDate(time.Now().UTC())
+	from := today - settings.LeaderboardDays + 1
+	server := guildId != ""
+
+	entries, attempts, err := b.leaderboard(channelId, guildId, today)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting leaderboard: %w", err)
+	}
+
+	rows := leaderboardRows(entries, attempts, from, today, userId, settings.Scoring)
+	pages := leaderboardPages(len(rows))
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	title, description := "Leaderboard", fmt.Sprintf("Scores of the last %d days, up to Wordle %d.",
+		settings.LeaderboardDays, today)
+	if server {
+		title = "Server leaderboard"
+		description = fmt.Sprintf("Scores of the last %d days in every tracked channel of this server, up to "+
+			"Wordle %d. Players who posted a wordle in more than one channel count their first result.",
+			settings.LeaderboardDays, today)
+	}
+	embed := newEmbed(title, description, colorInfo)
+
+	position := fmt.Sprintf("Page %d of %d", page+1, pages)
+	for _, row := range rows {
+		if row.Highlight {
+			position = fmt.Sprintf("Rank #%d of %d · %s", row.Rank, len(rows), position)
+		}
+	}
+	embed.Footer = scoringFooter(position, settings)
+
+	data := &discordgo.InteractionResponseData{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	}
+	if pages > 1 {
+		data.Components = []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "◀ Previous",
+						Style:    discordgo.SecondaryButton,
+						Disabled: page == 0,
+						CustomID: leaderboardButtonId(page-1, userId, server),
+					},
+					discordgo.Button{
+						Label:    "Next ▶",
+						Style:    discordgo.SecondaryButton,
+						Disabled: page == pages-1,
+						CustomID: leaderboardButtonId(page+1, userId, server),
+					},
+				},
+			},
+		}
+	}
+
+	pageRows := leaderboardPageRows(rows, page)
+
+	png, err := leaderboardImage(title, today, settings.LeaderboardDays, pageRows)
+	if err != nil {
+		log.Errorf("rendering leaderboard image, falling back to text: %v\n", err)
+		embed.Description += "\n\n" + leaderboardText(pageRows)
+		return data, nil, nil
+	}
+
+	embed.Image = &discordgo.MessageEmbedImage{URL: "attachment://" + leaderboardImageName}
+	return data, []*discordgo.File{
+		{Name: leaderboardImageName, ContentType: "image/png", Reader: png},
+	}, nil
+}
+
+// leaderboardPages returns the number of pages of a leaderboard with the given number of players. Empty leaderboards
+// have a single, empty, page.
+func leaderboardPages(players int) int {
+	if players == 0 {
+		return 1
+	}
+	return (players + leaderboardPageSize - 1) / leaderboardPageSize
+}
+
+// leaderboardPageRows returns the rows of a page of the leaderboard. The highlighted row is added at the end of pages
+// it's not in.
+func leaderboardPageRows(rows []render.LeaderboardRow, page int) []render.LeaderboardRow {
+	start := page * leaderboardPageSize
+	end := start + leaderboardPageSize
+	if start > len(rows) {
+		start = len(rows)
+	}
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	pageRows := append([]render.LeaderboardRow(nil), rows[start:end]...)
+	for n, row := range rows {
+		if row.Highlight && (n < start || n >= end) {
+			pageRows = append(pageRows, row)
+		}
+	}
+
+	return pageRows
+}
+
+// leaderboardImage renders rows of the leaderboard of the given number of days as a PNG image.
+func leaderboardImage(title string, today int, days int, rows []render.LeaderboardRow) (io.Reader, error) {
+	img := render.Leaderboard(title, fmt.Sprintf("Last %d days, up to Wordle %d", days, today), rows)
+
+	var buf bytes.Buffer
+	err := render.EncodePNG(&buf, img)
+	if err != nil {
+		return nil, fmt.Errorf("encoding leaderboard image: %w", err)
+	}
+
+	return &buf, nil
+}
+
+// leaderboardText lists rows of the leaderboard, one per line.
+func leaderboardText(rows []render.LeaderboardRow) string {
+	if len(rows) == 0 {
+		return "Nobody played yet."
+	}
+
+	var builder strings.Builder
+	for _, row := range rows {
+		name := row.Name
+		if row.Highlight {
+			name = "__" + name + "__"
+		}
+		fmt.Fprintf(&builder, "**%d.** %s · %.1f points · %.2f avg. attempts · %d played\n",
+			row.Rank, name, row.Score, row.AvgAttempts, row.Played)
+	}
+	return builder.String()
+}
+
+// leaderboardRows builds the rows of a rendered leaderboard. The trend of each player has the score of each day
+// between from and to, with the given scoring scheme, or NaN for the days they didn't play.
+func leaderboardRows(entries []db.LeaderboardEntry, attempts []db.Attempt, from int, to int, userId string,
+	scoring string) []render.LeaderboardRow {
+	trends := make(map[string][]float64)
+	for _, e := range entries {
+		trend := make([]float64, to-from+1)
+		for n := range trend {
+			trend[n] = math.NaN()
+		}
+		trends[e.UserId] = trend
+	}
+
+	for _, a := range attempts {
+		trend, ok := trends[a.UserId]
+		if !ok || a.Day < from || a.Day > to {
+			continue
+		}
+		trend[a.Day-from] = attemptScore(scoring, a)
+	}
+
+	rows := make([]render.LeaderboardRow, len(entries))
+	for n, e := range entries {
+		rows[n] = render.LeaderboardRow{
+			Rank:        n + 1,
+			UserId:      e.UserId,
+			Name:        e.Username,
+			Score:       e.TotalScore,
+			AvgAttempts: e.AvgAttempts,
+			Played:      e.Played,
+			Trend:       trends[e.UserId],
+			Highlight:   e.UserId == userId,
+		}
+	}
+
+	return rows
+}
+
+// attachmentRef names a file uploaded with a message in its attachments.
+type attachmentRef struct {
+	Id       int    `json:"id"`
+	Filename string `json:"filename"`
+}
+
+// replacingFilesData is the data of an interaction response that lists the files uploaded with it as the only
+// attachments of the message. discordgo doesn't support the attachments field, without which updated messages keep
+// their old files next to the new ones.
+type replacingFilesData struct {
+	*discordgo.InteractionResponseData
+	Attachments []attachmentRef `json:"attachments"`
+}
+
+type replacingFilesResponse struct {
+	Type discordgo.InteractionResponseType `json:"type"`
+	Data replacingFilesData                `json:"data"`
+}
+
+// respondReplacingFiles responds to an interaction with a message whose attachments are exactly the given files.
+func (b *WordleBot) respondReplacingFiles(i *discordgo.InteractionCreate, responseType discordgo.InteractionResponseType,
+	data *discordgo.InteractionResponseData, files []*discordgo.File) error {
+	resp := replacingFilesResponse{
+		Type: responseType,
+		Data: replacingFilesData{InteractionResponseData: data, Attachments: []attachmentRef{}},
+	}
+	for n, f := range files {
+		resp.Data.Attachments = append(resp.Data.Attachments, attachmentRef{Id: n, Filename: f.Name})
+	}
+
+	contentType, body, err := discordgo.MultipartBodyWithJSON(resp, files)
+	if err != nil {
+		return fmt.Errorf("encoding response: %w", err)
+	}
+
+	endpoint := discordgo.EndpointInteractionResponse(i.ID, i.Token)
+	_, err = b.session.RequestWithLockedBucket("POST", endpoint, contentType, body,
+		b.session.Ratelimiter.LockBucket(endpoint), 0)
+	return err
+}
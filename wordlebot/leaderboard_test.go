@@ -0,0 +1,148 @@
+package wordlebot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/render"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestLeaderboardRows(t *testing.T) {
+	entries := []db.LeaderboardEntry{
+		{UserId: "1", Username: "alice", TotalScore: 40, AvgAttempts: 3, Played: 2},
+		{UserId: "2", Username: "bob", TotalScore: 2, AvgAttempts: 6, Played: 1},
+	}
+	attempts := []db.Attempt{
+		{UserId: "1", Day: 10, Attempts: 3, Success: true},
+		{UserId: "1", Day: 12, Attempts: 2, Success: true},
+		{UserId: "2", Day: 12, Attempts: 6, Success: false},
+		{UserId: "3", Day: 11, Attempts: 4, Success: true},
+	}
+
+	rows := leaderboardRows(entries, attempts, 10, 12, "2", ScoringClassic)
+
+	if len(rows) != 2 {
+		t.Fatalf("leaderboardRows() returned %d rows, want 2", len(rows))
+	}
+	if rows[0].Rank != 1 || rows[1].Rank != 2 || rows[0].Highlight || !rows[1].Highlight {
+		t.Fatalf("leaderboardRows() = %+v, want alice first and bob second and highlighted", rows)
+	}
+
+	wantTrends := [][]float64{{18, math.NaN(), 27}, {math.NaN(), math.NaN(), 2}}
+	for n, want := range wantTrends {
+		got := rows[n].Trend
+		if len(got) != len(want) {
+			t.Fatalf("row %d trend = %v, want %v", n, got, want)
+		}
+		for d := range want {
+			if got[d] != want[d] && !(math.IsNaN(got[d]) && math.IsNaN(want[d])) {
+				t.Fatalf("row %d trend = %v, want %v", n, got, want)
+			}
+		}
+	}
+}
+
+func TestLeaderboardPages(t *testing.T) {
+	tests := []struct {
+		players int
+		want    int
+	}{
+		{players: 0, want: 1},
+		{players: 1, want: 1},
+		{players: leaderboardPageSize, want: 1},
+		{players: leaderboardPageSize + 1, want: 2},
+		{players: 3 * leaderboardPageSize, want: 3},
+	}
+
+	for _, tt := range tests {
+		if got := leaderboardPages(tt.players); got != tt.want {
+			t.Errorf("leaderboardPages(%d) = %d, want %d", tt.players, got, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardPageRows(t *testing.T) {
+	rows := make([]render.LeaderboardRow, 25)
+	for n := range rows {
+		rows[n].Rank = n + 1
+	}
+	rows[21].Highlight = true
+
+	ranks := func(rows []render.LeaderboardRow) []int {
+		var r []int
+		for _, row := range rows {
+			r = append(r, row.Rank)
+		}
+		return r
+	}
+
+	tests := []struct {
+		page int
+		want []int
+	}{
+		{page: 0, want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 22}},
+		{page: 2, want: []int{21, 22, 23, 24, 25}},
+		{page: 3, want: []int{22}},
+	}
+
+	for _, tt := range tests {
+		got := ranks(leaderboardPageRows(rows, tt.page))
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("leaderboardPageRows(page %d) ranks = %v, want %v", tt.page, got, tt.want)
+		}
+	}
+}
+
+func TestLeaderboardButtonId(t *testing.T) {
+	page, userId, server, ok := parseLeaderboardButton(leaderboardButtonId(3, "1234", false))
+	if !ok || page != 3 || userId != "1234" || server {
+		t.Fatalf("parseLeaderboardButton() = %d, %q, %v, %v, want 3, \"1234\", false, true", page, userId, server, ok)
+	}
+
+	page, userId, server, ok = parseLeaderboardButton(leaderboardButtonId(1, "1234", true))
+	if !ok || page != 1 || userId != "1234" || !server {
+		t.Fatalf("parseLeaderboardButton() = %d, %q, %v, %v, want 1, \"1234\", true, true", page, userId, server, ok)
+	}
+
+	if _, _, _, ok := parseLeaderboardButton("leaderboard:x:1234"); ok {
+		t.Fatal("parseLeaderboardButton() accepted an invalid page")
+	}
+	if _, _, _, ok := parseLeaderboardButton("leaderboard:1:1234:galaxy"); ok {
+		t.Fatal("parseLeaderboardButton() accepted an invalid scope")
+	}
+}
+
+func TestReplacingFilesResponseJSON(t *testing.T) {
+	resp := replacingFilesResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: replacingFilesData{
+			InteractionResponseData: &discordgo.InteractionResponseData{Content: "hi"},
+			Attachments:             []attachmentRef{{Id: 0, Filename: leaderboardImageName}},
+		},
+	}
+
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded struct {
+		Type int `json:"type"`
+		Data struct {
+			Content     string          `json:"content"`
+			Attachments []attachmentRef `json:"attachments"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if decoded.Type != int(discordgo.InteractionResponseUpdateMessage) || decoded.Data.Content != "hi" ||
+		len(decoded.Data.Attachments) != 1 || decoded.Data.Attachments[0].Filename != leaderboardImageName {
+		t.Fatalf("json.Marshal() = %s, want the response data with the attachments", got)
+	}
+}
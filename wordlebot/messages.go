@@ -1,6 +1,7 @@
 package wordlebot
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,30 +10,105 @@ import (
 	"github.com/bwmarrin/discordgo"
 	log "github.com/sirupsen/logrus"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
 func (b *WordleBot) MessageCreateHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if m.Author == nil || (s.State.User != nil && m.Author.ID == s.State.User.ID) {
+		return
+	}
+
 	tracked, _ := b.repository.IsTrackedChannel(m.ChannelID)
 	if !tracked {
 		return
 	}
 
+	settings := b.channelSettings(m.ChannelID)
+	if settings.SpoilerGuard != SpoilerGuardOff && b.guardSpoilers(m.Message, settings) {
+		return
+	}
+
+	b.recordLiveMessage(m.Message, settings)
+}
+
+// recordLiveMessage saves a new message if it is a wordle copy/paste and reacts to it according to the settings of
+// its channel.
+func (b *WordleBot) recordLiveMessage(m *discordgo.Message, settings channelSettings) {
 	attempt, ok := wordle.ParseCopyPaste(m.Content)
 	if !ok {
 		return
 	}
 
-	err := b.saveWordleMessage(m.Message, attempt)
+	// The leader before the copy/paste is saved tells whether its author takes first place with it.
+	var leader string
+	var leaderErr error
+	if settings.ReactionSet == ReactionSetResults {
+		leader, leaderErr = b.leader(m.ChannelID)
+		if leaderErr != nil {
+			log.Errorf("failed to get leader: %v\n", leaderErr)
+		}
+	}
+
+	author, err := b.saveWordleMessage(m, attempt)
 	if err != nil {
 		log.Errorf("failed to save wordle message: %v\n", err)
 		return
 	}
+	if author == nil {
+		return
+	}
+
+	var ctx resultContext
+	if settings.ReactionSet == ReactionSetResults {
+		ctx = b.liveResultContext(m, attempt)
+		if leaderErr == nil {
+			ctx.FirstPlace = b.tookFirstPlace(m.ChannelID, m.Author.ID, leader)
+		}
+	}
 
-	err = b.session.MessageReactionAdd(m.ChannelID, m.ID, "✅")
+	b.reactions.React(m.ChannelID, m.ID, resultReactions(settings.ReactionSet, attempt, ctx)...)
+}
+
+// liveResultContext gathers the streak of the author of a new copy/paste.
+func (b *WordleBot) liveResultContext(m *discordgo.Message, attempt *wordle.Attempt) resultContext {
+	var ctx resultContext
+	var err error
+
+	ctx.Streak, err = b.repository.Streak(m.ChannelID, m.Author.ID, attempt.Day)
 	if err != nil {
-		log.Errorf("failed to add reaction: %v\n", err)
+		log.Errorf("failed to get streak: %v\n", err)
 	}
+
+	return ctx
+}
+
+// tookFirstPlace tells whether a player leads the leaderboard of a channel now that the given previous leader was
+// someone else.
+func (b *WordleBot) tookFirstPlace(channelId string, userId string, previousLeader string) bool {
+	if previousLeader == userId {
+		return false
+	}
+
+	leader, err := b.leader(channelId)
+	if err != nil {
+		log.Errorf("failed to get leader: %v\n", err)
+		return false
+	}
+
+	return leader == userId
+}
+
+// leader returns the user id of the first place of the leaderboard of a channel, or empty if nobody played.
+func (b *WordleBot) leader(channelId string) (string, error) {
+	entries, _, err := b.leaderboard(channelId, "", wordle.DayForDate(time.Now().UTC()))
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	return entries[0].UserId, nil
 }
 
 func (b *WordleBot) DeleteMessageHandler(s *discordgo.Session, m *discordgo.MessageDelete) {
@@ -74,7 +150,7 @@ func (b *WordleBot) UpdateMessageHandler(s *discordgo.Session, m *discordgo.Mess
 		}
 	} else {
 		if ok {
-			err := b.saveWordleMessage(m.Message, attempt)
+			_, err := b.saveWordleMessage(m.Message, attempt)
 			if err != nil {
 				log.Errorf("failed to save wordle message: %v\n", err)
 			}
@@ -87,15 +163,29 @@ type ProcessResult struct {
 	TotalMessages  int
 }
 
-func (b *WordleBot) ProcessChannelMessages(channelId string) (*ProcessResult, error) {
+// ProcessChannelMessages scans the whole history of a channel for wordle copy/pastes.
+// If progress is not nil, it is called with the partial result after each page of messages.
+func (b *WordleBot) ProcessChannelMessages(ctx context.Context, channelId string, progress func(ProcessResult)) (*ProcessResult, error) {
 	messages, err := b.session.ChannelMessages(channelId, 100, "", "", "")
 	if err != nil {
 		return nil, fmt.Errorf("getting channel messages: %v", err)
 	}
 
+	settings, err := b.settings.Get(channelId)
+	if err != nil {
+		return nil, fmt.Errorf("getting channel settings: %v", err)
+	}
+	reactionSet := settings.ReactionSet
+
+	// Messages fetched from the history of a channel don't say which guild they were posted in.
+	guildId := b.channelGuild(channelId)
+
 	var result ProcessResult
 
 	for len(messages) > 0 {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 
 		result.TotalMessages += len(messages)
 		for _, m := range messages {
@@ -104,19 +194,28 @@ func (b *WordleBot) ProcessChannelMessages(channelId string) (*ProcessResult, er
 				continue
 			}
 
-			err := b.saveWordleMessage(m, attempt)
+			if m.GuildID == "" {
+				m.GuildID = guildId
+			}
+			author, err := b.saveWordleMessage(m, attempt)
 			if err != nil {
 				return nil, fmt.Errorf("saving wordle message: %v", err)
 			}
+			if author == nil {
+				continue
+			}
 
-			err = b.session.MessageReactionAdd(m.ChannelID, m.ID, "✅")
-			if err != nil {
-				log.Errorf("failed to add reaction: %v\n", err)
+			if !b.config.SkipHistoricalReactions {
+				b.reactions.ReactHistorical(m.ChannelID, m.ID, resultReactions(reactionSet, attempt, resultContext{})...)
 			}
 
 			result.WordleMessages++
 		}
 
+		if progress != nil {
+			progress(result)
+		}
+
 		messages, err = b.session.ChannelMessages(channelId, 100, messages[len(messages)-1].ID, "", "")
 		if err != nil {
 			return nil, fmt.Errorf("getting channel messages: %v", err)
@@ -126,18 +225,34 @@ func (b *WordleBot) ProcessChannelMessages(channelId string) (*ProcessResult, er
 	return &result, nil
 }
 
-func (b *WordleBot) saveWordleMessage(m *discordgo.Message, attempt *wordle.Attempt) error {
+// saveWordleMessage saves the copy/paste of a message as an attempt of the player it belongs to, and returns that
+// player. Returns nil without saving anything for messages of the bot that don't belong to a player.
+func (b *WordleBot) saveWordleMessage(m *discordgo.Message, attempt *wordle.Attempt) (*discordgo.User, error) {
+	author, err := b.messageAuthor(m)
+	if err != nil {
+		return nil, fmt.Errorf("getting author: %w", err)
+	}
+	if author == nil {
+		return nil, nil
+	}
+
 	attemptsJson, err := json.Marshal(attempt.AttemptsDetail)
 	if err != nil {
-		return errors.New("failed to marshal attempts detail")
+		return nil, errors.New("failed to marshal attempts detail")
+	}
+
+	guildId := m.GuildID
+	if guildId == "" {
+		guildId = b.channelGuild(m.ChannelID)
 	}
 
 	err = b.repository.SaveAttempt(db.Attempt{
 		MessageId:    m.ID,
 		ChannelId:    m.ChannelID,
-		UserId:       m.Author.ID,
+		GuildId:      guildId,
+		UserId:       author.ID,
 		Day:          attempt.Day,
-		UserName:     m.Author.Username,
+		UserName:     author.Username,
 		Attempts:     attempt.Attempts,
 		MaxAttempts:  attempt.MaxAttempts,
 		Success:      attempt.Success,
@@ -147,10 +262,99 @@ func (b *WordleBot) saveWordleMessage(m *discordgo.Message, attempt *wordle.Atte
 	})
 
 	if err != nil {
-		return fmt.Errorf("saving attempt: %w", err)
+		return nil, fmt.Errorf("saving attempt: %w", err)
+	}
+
+	return author, nil
+}
+
+// HandleRecordResultInteraction records the copy/paste of the message a message command was used on, for messages
+// the bot missed, e.g. posted before the channel was tracked or in untracked threads.
+func (b *WordleBot) HandleRecordResultInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.ApplicationCommandData()
+
+	var m *discordgo.Message
+	if data.Resolved != nil {
+		m = data.Resolved.Messages[data.TargetID]
+	}
+	if m == nil {
+		return fmt.Errorf("target message %s of message command not resolved", data.TargetID)
+	}
+	if m.ChannelID == "" {
+		m.ChannelID = i.ChannelID
+	}
+	if m.GuildID == "" {
+		m.GuildID = i.GuildID
+	}
+
+	attempt, ok := wordle.ParseCopyPaste(m.Content)
+	if !ok {
+		return respondEphemeralEmbed(s, i, newEmbed("", "This message isn't a wordle copy/paste.", colorWarning))
+	}
+
+	author, err := b.saveWordleMessage(m, attempt)
+	if err != nil {
+		respondEphemeralEmbed(s, i, newEmbed("", problemMessage, colorFailure))
+		return fmt.Errorf("saving wordle message: %w", err)
+	}
+	if author == nil {
+		return respondEphemeralEmbed(s, i, newEmbed("", "This message of the bot doesn't belong to a player.",
+			colorWarning))
+	}
+
+	reactionSet := b.channelSettings(m.ChannelID).ReactionSet
+	b.reactions.React(m.ChannelID, m.ID, resultReactions(reactionSet, attempt, resultContext{})...)
+
+	result := "X"
+	if attempt.Success {
+		result = fmt.Sprint(attempt.Attempts)
+	}
+
+	return respondEphemeralEmbed(s, i, newEmbed("", fmt.Sprintf("Recorded Wordle %d %s/%d%s for %s.", attempt.Day,
+		result, attempt.MaxAttempts, hardModeMark(attempt.HardMode), author.Username),
+		resultColor(attempt.Success, attempt.Attempts)))
+}
+
+// messageAuthor returns the player a copy/paste belongs to. Copy/pastes the bot posts on behalf of a player
+// (reposted spoilers, games played in discord) belong to the owner recorded when they were posted. Returns nil for
+// other messages of the bot.
+func (b *WordleBot) messageAuthor(m *discordgo.Message) (*discordgo.User, error) {
+	if m.Author == nil || b.session.State.User == nil || m.Author.ID != b.session.State.User.ID {
+		return m.Author, nil
+	}
+
+	owner, err := b.repository.MessageOwner(m.ChannelID, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if owner == nil {
+		return nil, nil
+	}
+
+	return &discordgo.User{ID: owner.UserId, Username: owner.UserName}, nil
+}
+
+// postForPlayer posts a message on behalf of a player and records them as its owner, so the copy/paste in it, if
+// any, counts for them when the channel is scanned again. The message returned has the player as its author.
+func (b *WordleBot) postForPlayer(channelId string, player *discordgo.User, data *discordgo.MessageSend) (
+	*discordgo.Message, error) {
+	msg, err := b.session.ChannelMessageSendComplex(channelId, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.repository.SaveMessageOwner(db.MessageOwner{
+		ChannelId: channelId,
+		MessageId: msg.ID,
+		UserId:    player.ID,
+		UserName:  player.Username,
+	})
+	if err != nil {
+		log.Errorf("failed to save owner of message %s: %v\n", msg.ID, err)
 	}
 
-	return nil
+	msg.Author = player
+	return msg, nil
 }
 
 func SanitizeMessage(message string) string {
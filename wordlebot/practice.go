@@ -0,0 +1,132 @@
+package wordlebot
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/game"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+)
+
+func (b *WordleBot) HandlePracticeInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+
+	session := b.games.Get(GameKindPractice, "", user.ID)
+	if session == nil {
+		word, ok := randomPastAnswer(time.Now())
+		if !ok {
+			return respondEphemeral(s, i, "Sorry, there are no words to practice with.")
+		}
+
+		var hardMode bool
+		if opt, ok := subCommandOptions(i)["hard"]; ok {
+			hardMode = opt.BoolValue()
+		}
+
+		session = &gameSession{
+			Kind: GameKindPractice,
+			Game: game.New(word, game.Options{
+				HardMode:    hardMode,
+				IsValidWord: wordle.IsValidGuess,
+			}),
+		}
+		b.games.Set(user.ID, session)
+	}
+
+	return openGuessModal(s, i, session)
+}
+
+// randomPastAnswer picks the answer of a random puzzle that is already over everywhere, so practicing never spoils
+// the daily puzzles.
+func randomPastAnswer(now time.Time) (string, bool) {
+	days := lastFinishedDay(now) + 1
+	if days > wordle.AnswerCount() {
+		days = wordle.AnswerCount()
+	}
+	if days <= 0 {
+		return "", false
+	}
+
+	return wordle.Answer(rand.Intn(days))
+}
+
+// savePracticeResult saves the result of a finished practice game. Practice results are never posted in the channel.
+func (b *WordleBot) savePracticeResult(user *discordgo.User, session *gameSession) error {
+	g := session.Game
+
+	attemptsJson, err := json.Marshal(strings.Split(g.Grid(), "\n"))
+	if err != nil {
+		return fmt.Errorf("marshalling attempts detail: %w", err)
+	}
+
+	err = b.repository.SavePracticeResult(db.PracticeResult{
+		UserId:       user.ID,
+		UserName:     user.Username,
+		Word:         strings.ToLower(g.Target()),
+		Attempts:     len(g.Guesses()),
+		MaxAttempts:  g.MaxGuesses(),
+		Success:      g.Won(),
+		HardMode:     g.HardMode(),
+		AttemptsJson: string(attemptsJson),
+	})
+	if err != nil {
+		return fmt.Errorf("saving practice result: %w", err)
+	}
+
+	return nil
+}
+
+// newPracticeStats computes the stats of the given practice results, most recent first. Streaks count consecutive
+// games won.
+func newPracticeStats(results []db.PracticeResult) playerStats {
+	var stats playerStats
+
+	streak := 0
+	for n := len(results) - 1; n >= 0; n-- {
+		r := results[n]
+		stats.Played++
+
+		if !r.Success {
+			streak = 0
+			continue
+		}
+
+		stats.Won++
+		streak++
+		if streak > stats.MaxStreak {
+			stats.MaxStreak = streak
+		}
+		stats.addWin(r.Attempts)
+	}
+	stats.CurrentStreak = streak
+
+	return stats
+}
+
+func (b *WordleBot) HandlePracticeStatsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+
+	results, err := b.repository.PracticeResults(user.ID)
+	if err != nil {
+		respondEphemeral(s, i, problemMessage)
+		return fmt.Errorf("getting practice results: %w", err)
+	}
+
+	if len(results) == 0 {
+		return respondEphemeral(s, i, "You haven't practiced yet. Start with `/wordle practice`!")
+	}
+
+	// Practice is private, so its stats are only public when asked for, whatever the channel default.
+	var public bool
+	if opt, ok := subCommandOptions(i)["public"]; ok {
+		public = opt.BoolValue()
+	}
+
+	embed := statsEmbed(fmt.Sprintf("Practice stats of %s", user.Username), newPracticeStats(results))
+	return respondEmbedWithFlags(s, i, embed, visibilityFlags(public))
+}
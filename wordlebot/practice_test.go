@@ -0,0 +1,51 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+func TestPracticeStats(t *testing.T) {
+	// Most recent first
+	results := []db.PracticeResult{
+		{Attempts: 3, Success: true},
+		{Attempts: 6, Success: false},
+		{Attempts: 4, Success: true},
+		{Attempts: 3, Success: true},
+		{Attempts: 2, Success: true},
+		{Attempts: 6, Success: false},
+	}
+
+	got := newPracticeStats(results)
+	want := playerStats{
+		Played:        6,
+		Won:           4,
+		CurrentStreak: 1,
+		MaxStreak:     3,
+		Distribution:  [6]int{0, 1, 2, 1, 0, 0},
+	}
+
+	if got != want {
+		t.Fatalf("newPracticeStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRandomPastAnswer(t *testing.T) {
+	// Wordle 217 is over everywhere a day later
+	now := time.Date(2022, time.January, 23, 13, 0, 0, 0, time.UTC)
+	past := make(map[string]bool)
+	for day := 0; day <= 217; day++ {
+		answer, _ := wordle.Answer(day)
+		past[answer] = true
+	}
+
+	for n := 0; n < 100; n++ {
+		word, ok := randomPastAnswer(now)
+		if !ok || !past[word] {
+			t.Fatalf("randomPastAnswer() = %q, %v, want an answer of a day up to 217", word, ok)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package wordlebot
+
+import (
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+// Reaction sets a channel can choose from to react to wordle copy/pastes.
+const (
+	// ReactionSetClassic reacts with a checkmark to every copy/paste.
+	ReactionSetClassic = "classic"
+	// ReactionSetResults reacts according to the result, the streak and the leaderboard position of the player.
+	ReactionSetResults = "results"
+	// ReactionSetNone records copy/pastes without reacting to them.
+	ReactionSetNone = "none"
+)
+
+var reactionSets = []string{ReactionSetClassic, ReactionSetResults, ReactionSetNone}
+
+var guessCountEmojis = []string{"1️⃣", "2️⃣", "3️⃣", "4️⃣", "5️⃣", "6️⃣", "7️⃣", "8️⃣", "9️⃣"}
+
+// resultContext has the information about a copy/paste, besides the attempt itself, used to pick reactions.
+// Fields are left empty for historical copy/pastes, where they are not meaningful.
+type resultContext struct {
+	// Streak is the number of consecutive days the player solved the wordle, ending with this attempt.
+	Streak int
+	// FirstPlace is true if this attempt took the player to first place on the leaderboard.
+	FirstPlace bool
+}
+
+// resultReactions returns the emojis to react with to a copy/paste, in order, for the given reaction set.
+func resultReactions(reactionSet string, attempt *wordle.Attempt, ctx resultContext) []string {
+	switch reactionSet {
+	case ReactionSetNone:
+		return nil
+	case ReactionSetResults:
+	default:
+		return []string{"✅"}
+	}
+
+	var emojis []string
+	if attempt.Success {
+		if attempt.Attempts <= 2 {
+			emojis = append(emojis, "🎯")
+		}
+		if attempt.Attempts >= 1 && attempt.Attempts <= len(guessCountEmojis) {
+			emojis = append(emojis, guessCountEmojis[attempt.Attempts-1])
+		}
+	} else {
+		emojis = append(emojis, "💀")
+	}
+
+	if isStreakMilestone(ctx.Streak) {
+		emojis = append(emojis, "🔥")
+	}
+
+	if ctx.FirstPlace {
+		emojis = append(emojis, "👑")
+	}
+
+	return emojis
+}
+
+// isStreakMilestone returns true for streaks worth celebrating: every 5 days up to a month, then every 10.
+func isStreakMilestone(streak int) bool {
+	if streak < 5 {
+		return false
+	}
+	if streak <= 30 {
+		return streak%5 == 0
+	}
+	return streak%10 == 0
+}
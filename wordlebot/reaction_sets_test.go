@@ -0,0 +1,143 @@
+package wordlebot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+)
+
+func TestResultReactions(t *testing.T) {
+	solvedIn := func(attempts int) *wordle.Attempt {
+		return &wordle.Attempt{Day: 450, Attempts: attempts, MaxAttempts: 6, Success: true}
+	}
+	failed := &wordle.Attempt{Day: 450, Attempts: 6, MaxAttempts: 6, Success: false}
+
+	tests := []struct {
+		name        string
+		reactionSet string
+		attempt     *wordle.Attempt
+		ctx         resultContext
+		want        []string
+	}{
+		{
+			name:        "classic",
+			reactionSet: ReactionSetClassic,
+			attempt:     solvedIn(4),
+			ctx:         resultContext{Streak: 10, FirstPlace: true},
+			want:        []string{"✅"},
+		},
+		{
+			name:        "classic failed",
+			reactionSet: ReactionSetClassic,
+			attempt:     failed,
+			want:        []string{"✅"},
+		},
+		{
+			name:        "unknown set reacts like classic",
+			reactionSet: "sparkles",
+			attempt:     solvedIn(4),
+			want:        []string{"✅"},
+		},
+		{
+			name:        "none",
+			reactionSet: ReactionSetNone,
+			attempt:     solvedIn(1),
+			ctx:         resultContext{Streak: 10, FirstPlace: true},
+			want:        nil,
+		},
+		{
+			name:        "results in one guess",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(1),
+			want:        []string{"🎯", "1️⃣"},
+		},
+		{
+			name:        "results in two guesses",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(2),
+			want:        []string{"🎯", "2️⃣"},
+		},
+		{
+			name:        "results in four guesses",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(4),
+			want:        []string{"4️⃣"},
+		},
+		{
+			name:        "results failed",
+			reactionSet: ReactionSetResults,
+			attempt:     failed,
+			want:        []string{"💀"},
+		},
+		{
+			name:        "results failed keeps first place",
+			reactionSet: ReactionSetResults,
+			attempt:     failed,
+			ctx:         resultContext{FirstPlace: true},
+			want:        []string{"💀", "👑"},
+		},
+		{
+			name:        "results with a streak milestone",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(3),
+			ctx:         resultContext{Streak: 5},
+			want:        []string{"3️⃣", "🔥"},
+		},
+		{
+			name:        "results with a streak between milestones",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(3),
+			ctx:         resultContext{Streak: 6},
+			want:        []string{"3️⃣"},
+		},
+		{
+			name:        "results taking first place",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(5),
+			ctx:         resultContext{FirstPlace: true},
+			want:        []string{"5️⃣", "👑"},
+		},
+		{
+			name:        "results with everything",
+			reactionSet: ReactionSetResults,
+			attempt:     solvedIn(2),
+			ctx:         resultContext{Streak: 40, FirstPlace: true},
+			want:        []string{"🎯", "2️⃣", "🔥", "👑"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultReactions(tt.reactionSet, tt.attempt, tt.ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("resultReactions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsStreakMilestone(t *testing.T) {
+	tests := []struct {
+		streak int
+		want   bool
+	}{
+		{0, false},
+		{1, false},
+		{4, false},
+		{5, true},
+		{6, false},
+		{15, true},
+		{25, true},
+		{30, true},
+		{35, false},
+		{40, true},
+		{45, false},
+		{100, true},
+	}
+
+	for _, tt := range tests {
+		if got := isStreakMilestone(tt.streak); got != tt.want {
+			t.Errorf("isStreakMilestone(%d) = %v, want %v", tt.streak, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package wordlebot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// reactionInterval is the minimum time between two reactions in the same channel.
+	// Discord allows roughly one reaction every 250ms per channel.
+	reactionInterval = 300 * time.Millisecond
+	// defaultRetryAfter is how long a channel is paused when discord rate limits us without saying for how long.
+	defaultRetryAfter = 5 * time.Second
+)
+
+type reaction struct {
+	channelId string
+	messageId string
+	emoji     string
+	// historical reactions are for messages found while scanning the history of a channel.
+	historical bool
+}
+
+// reactionDispatcher adds reactions to messages in the background, pacing them per channel to stay within
+// Discord's reaction rate limit buckets. Reactions to live messages always go before reactions to historical
+// messages, so a big import doesn't delay the feedback for new copy/pastes.
+//
+// The dispatcher sends reactions through a REST-only session of its own that doesn't retry rate limited requests,
+// so a rate limited channel is paused here instead of blocking the reactions of every other channel.
+type reactionDispatcher struct {
+	session *discordgo.Session
+
+	mu      sync.Mutex
+	live    map[string][]reaction
+	backlog map[string][]reaction
+	next    map[string]time.Time
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReactionDispatcher(token string) (*reactionDispatcher, error) {
+	session, err := discordgo.New(token)
+	if err != nil {
+		return nil, fmt.Errorf("creating session: %w", err)
+	}
+	session.ShouldRetryOnRateLimit = false
+
+	return &reactionDispatcher{
+		session: session,
+		live:    make(map[string][]reaction),
+		backlog: make(map[string][]reaction),
+		next:    make(map[string]time.Time),
+		wake:    make(chan struct{}, 1),
+	}, nil
+}
+
+func (d *reactionDispatcher) Start() {
+	var ctx context.Context
+	ctx, d.cancel = context.WithCancel(context.Background())
+
+	d.wg.Add(1)
+	go d.run(ctx)
+}
+
+// Stop stops the dispatcher. Reactions still pending are dropped.
+func (d *reactionDispatcher) Stop() {
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+}
+
+// React queues reactions to a live message. They are sent ahead of any historical reactions.
+func (d *reactionDispatcher) React(channelId string, messageId string, emojis ...string) {
+	d.enqueue(channelId, messageId, emojis, false)
+}
+
+// ReactHistorical queues reactions to a message found while scanning the history of a channel.
+func (d *reactionDispatcher) ReactHistorical(channelId string, messageId string, emojis ...string) {
+	d.enqueue(channelId, messageId, emojis, true)
+}
+
+func (d *reactionDispatcher) enqueue(channelId string, messageId string, emojis []string, historical bool) {
+	d.mu.Lock()
+	queue := d.queueFor(historical)
+	for _, emoji := range emojis {
+		queue[channelId] = append(queue[channelId], reaction{
+			channelId:  channelId,
+			messageId:  messageId,
+			emoji:      emoji,
+			historical: historical,
+		})
+	}
+	d.mu.Unlock()
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// queueFor returns the queue for live or historical reactions. Must be called with d.mu held.
+func (d *reactionDispatcher) queueFor(historical bool) map[string][]reaction {
+	if historical {
+		return d.backlog
+	}
+	return d.live
+}
+
+func (d *reactionDispatcher) run(ctx context.Context) {
+	defer d.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		for _, r := range d.due(time.Now()) {
+			d.send(r)
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		if wait, ok := d.nextWait(time.Now()); ok {
+			timer.Reset(wait)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// due pops the next reaction of every channel whose bucket is free at the given time.
+func (d *reactionDispatcher) due(now time.Time) []reaction {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var batch []reaction
+	for _, queue := range []map[string][]reaction{d.live, d.backlog} {
+		for channelId, pending := range queue {
+			if now.Before(d.next[channelId]) || len(pending) == 0 {
+				continue
+			}
+
+			batch = append(batch, pending[0])
+			if len(pending) == 1 {
+				delete(queue, channelId)
+			} else {
+				queue[channelId] = pending[1:]
+			}
+			d.next[channelId] = now.Add(reactionInterval)
+		}
+	}
+
+	return batch
+}
+
+// nextWait returns how long until the next pending reaction can be sent, or false if there are none pending.
+func (d *reactionDispatcher) nextWait(now time.Time) (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var earliest time.Time
+	found := false
+	for _, queue := range []map[string][]reaction{d.live, d.backlog} {
+		for channelId := range queue {
+			next := d.next[channelId]
+			if !found || next.Before(earliest) {
+				earliest, found = next, true
+			}
+		}
+	}
+
+	if !found {
+		return 0, false
+	}
+	if wait := earliest.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
+
+func (d *reactionDispatcher) send(r reaction) {
+	err := d.session.MessageReactionAdd(r.channelId, r.messageId, r.emoji)
+	if err == nil {
+		return
+	}
+
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := defaultRetryAfter
+		if rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil && rateLimitErr.RetryAfter > 0 {
+			retryAfter = rateLimitErr.RetryAfter
+		}
+
+		d.mu.Lock()
+		queue := d.queueFor(r.historical)
+		queue[r.channelId] = append([]reaction{r}, queue[r.channelId]...)
+		d.next[r.channelId] = time.Now().Add(retryAfter)
+		d.mu.Unlock()
+		return
+	}
+
+	log.Errorf("failed to add reaction: %v\n", err)
+}
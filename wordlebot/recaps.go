@@ -0,0 +1,315 @@
+package wordlebot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultRecapTime is the local time recaps are posted in channels without a daily summary time.
+const defaultRecapTime = "09:00"
+
+type recapPeriod struct {
+	Name string
+	// From and To are the first and last days of the period, both inclusive.
+	From int
+	To   int
+}
+
+// Length returns the number of days in the period.
+func (p recapPeriod) Length() int {
+	return p.To - p.From + 1
+}
+
+// Previous returns the period of the same length right before this one.
+func (p recapPeriod) Previous() recapPeriod {
+	return recapPeriod{
+		Name: p.Name,
+		From: p.From - p.Length(),
+		To:   p.From - 1,
+	}
+}
+
+// lastWeek returns the last full week, monday to sunday, before the date of now.
+func lastWeek(now time.Time) recapPeriod {
+	sinceSunday := (int(now.Weekday())+6)%7 + 1
+	to := wordle.DayForDate(now) - sinceSunday
+	return recapPeriod{Name: "Weekly", From: to - 6, To: to}
+}
+
+// lastMonth returns the last full calendar month before the date of now.
+func lastMonth(now time.Time) recapPeriod {
+	year, month, _ := now.Date()
+	firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return recapPeriod{
+		Name: "Monthly",
+		From: wordle.DayForDate(firstOfMonth.AddDate(0, -1, 0)),
+		To:   wordle.DayForDate(firstOfMonth) - 1,
+	}
+}
+
+// postRecaps posts the weekly and monthly recaps to the channels that have them enabled, once the period is over
+// and the local recap time has been reached.
+func (b *WordleBot) postRecaps(now time.Time) {
+	channels, err := b.repository.TrackedChannels()
+	if err != nil {
+		log.Errorf("getting channels for recaps: %v\n", err)
+		return
+	}
+
+	for _, channel := range channels {
+		settings, err := b.settings.Get(channel.ChannelId)
+		if err != nil {
+			log.Errorf("getting settings of channel %s for recaps: %v\n", channel.ChannelId, err)
+			continue
+		}
+
+		if !settings.WeeklyRecap && !settings.MonthlyRecap {
+			continue
+		}
+
+		recapTime := settings.SummaryTime
+		if recapTime == "" {
+			recapTime = defaultRecapTime
+		}
+
+		local := now.In(settings.Location())
+		if !clockReached(local, recapTime) {
+			continue
+		}
+
+		if week := lastWeek(local); settings.WeeklyRecap && channel.LastWeeklyRecapDay < week.To {
+			err = b.postRecap(channel.ChannelId, week, settings.Scoring)
+			if err == nil {
+				err = b.repository.SetLastWeeklyRecapDay(channel.ChannelId, week.To)
+			}
+			if err != nil {
+				log.Errorf("posting weekly recap to channel %s: %v\n", channel.ChannelId, err)
+			}
+		}
+
+		if month := lastMonth(local); settings.MonthlyRecap && channel.LastMonthlyRecapDay < month.To {
+			err = b.postRecap(channel.ChannelId, month, settings.Scoring)
+			if err == nil {
+				err = b.repository.SetLastMonthlyRecapDay(channel.ChannelId, month.To)
+			}
+			if err != nil {
+				log.Errorf("posting monthly recap to channel %s: %v\n", channel.ChannelId, err)
+			}
+		}
+	}
+}
+
+func (b *WordleBot) postRecap(channelId string, period recapPeriod, scoring string) error {
+	previous := period.Previous()
+	attempts, err := b.repository.AttemptsBetweenDays(channelId, previous.From, period.To)
+	if err != nil {
+		return fmt.Errorf("getting attempts: %w", err)
+	}
+
+	var current, before []db.Attempt
+	for _, a := range attempts {
+		if a.Day >= period.From {
+			current = append(current, a)
+		} else {
+			before = append(before, a)
+		}
+	}
+
+	_, err = b.session.ChannelMessageSend(channelId, recapMessage(period, current, before, scoring))
+	if err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+func recapMessage(period recapPeriod, attempts []db.Attempt, previous []db.Attempt, scoring string) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "🗓️ **%s recap: Wordle %d to %d**\n", period.Name, period.From, period.To)
+	if len(attempts) == 0 {
+		builder.WriteString("Nobody played in this period 😢\n")
+		return builder.String()
+	}
+
+	players := recapPlayers(attempts, scoring)
+	fmt.Fprintf(&builder, "👥 %d players, %d games\n\n", len(players), len(attempts))
+
+	for _, a := range recapAwards(attempts, previous, (period.Length()+1)/2, scoring) {
+		fmt.Fprintf(&builder, "%s **%s**: %s — %s\n", a.Emoji, a.Title, a.UserName, a.Detail)
+	}
+
+	return builder.String()
+}
+
+type award struct {
+	Emoji    string
+	Title    string
+	UserName string
+	Detail   string
+}
+
+type playerRecap struct {
+	UserId   string
+	UserName string
+	Score    float64
+	Solved   int
+	Escapes  int
+	attempts []int
+}
+
+func (p *playerRecap) Played() int {
+	return len(p.attempts)
+}
+
+func (p *playerRecap) AvgAttempts() float64 {
+	var sum int
+	for _, a := range p.attempts {
+		sum += a
+	}
+	return float64(sum) / float64(len(p.attempts))
+}
+
+func (p *playerRecap) StdDevAttempts() float64 {
+	avg := p.AvgAttempts()
+	var sum float64
+	for _, a := range p.attempts {
+		sum += (float64(a) - avg) * (float64(a) - avg)
+	}
+	return math.Sqrt(sum / float64(len(p.attempts)))
+}
+
+// recapPlayers aggregates attempts per player, in order of first appearance, scoring them with a scoring scheme.
+func recapPlayers(attempts []db.Attempt, scoring string) []*playerRecap {
+	var players []*playerRecap
+	byUser := make(map[string]*playerRecap)
+
+	for _, a := range attempts {
+		p, ok := byUser[a.UserId]
+		if !ok {
+			p = &playerRecap{UserId: a.UserId}
+			byUser[a.UserId] = p
+			players = append(players, p)
+		}
+
+		p.UserName = a.UserName
+		p.Score += attemptScore(scoring, a)
+		p.attempts = append(p.attempts, a.Attempts)
+		if a.Success {
+			p.Solved++
+			if a.Attempts == a.MaxAttempts {
+				p.Escapes++
+			}
+		}
+	}
+
+	return players
+}
+
+// recapAwards computes the awards for the attempts of a period. Previous are the attempts of the period before,
+// used to find the most improved player. Consistency only considers players with at least minGames games in the
+// period and no failures, improvement only players with minGames in both periods. Awards nobody qualifies for are
+// left out.
+func recapAwards(attempts []db.Attempt, previous []db.Attempt, minGames int, scoring string) []award {
+	var awards []award
+
+	players := recapPlayers(attempts, scoring)
+	if len(players) == 0 {
+		return awards
+	}
+
+	champion := players[0]
+	for _, p := range players[1:] {
+		if p.Score > champion.Score {
+			champion = p
+		}
+	}
+	awards = append(awards, award{
+		Emoji:    "🏆",
+		Title:    "Champion",
+		UserName: champion.UserName,
+		Detail:   fmt.Sprintf("%.0f points in %d games", champion.Score, champion.Played()),
+	})
+
+	var consistent *playerRecap
+	for _, p := range players {
+		if p.Played() < minGames || p.Solved != p.Played() {
+			continue
+		}
+		if consistent == nil || p.StdDevAttempts() < consistent.StdDevAttempts() {
+			consistent = p
+		}
+	}
+	if consistent != nil {
+		awards = append(awards, award{
+			Emoji:    "📏",
+			Title:    "Most consistent",
+			UserName: consistent.UserName,
+			Detail: fmt.Sprintf("%.2f average with a deviation of %.2f over %d games",
+				consistent.AvgAttempts(), consistent.StdDevAttempts(), consistent.Played()),
+		})
+	}
+
+	var luckiest *db.Attempt
+	for i, a := range attempts {
+		if a.Success && (luckiest == nil || a.Attempts < luckiest.Attempts) {
+			luckiest = &attempts[i]
+		}
+	}
+	if luckiest != nil {
+		awards = append(awards, award{
+			Emoji:    "🍀",
+			Title:    "Luckiest guess",
+			UserName: luckiest.UserName,
+			Detail:   fmt.Sprintf("solved Wordle %d in %d", luckiest.Day, luckiest.Attempts),
+		})
+	}
+
+	before := make(map[string]*playerRecap)
+	for _, p := range recapPlayers(previous, scoring) {
+		before[p.UserId] = p
+	}
+	var improved *playerRecap
+	var improvement float64
+	for _, p := range players {
+		b, ok := before[p.UserId]
+		if !ok || p.Played() < minGames || b.Played() < minGames {
+			continue
+		}
+		if diff := b.AvgAttempts() - p.AvgAttempts(); diff > improvement {
+			improved, improvement = p, diff
+		}
+	}
+	if improved != nil {
+		awards = append(awards, award{
+			Emoji:    "📈",
+			Title:    "Most improved",
+			UserName: improved.UserName,
+			Detail: fmt.Sprintf("average went from %.2f to %.2f",
+				before[improved.UserId].AvgAttempts(), improved.AvgAttempts()),
+		})
+	}
+
+	var escapist *playerRecap
+	for _, p := range players {
+		if p.Escapes > 0 && (escapist == nil || p.Escapes > escapist.Escapes) {
+			escapist = p
+		}
+	}
+	if escapist != nil {
+		awards = append(awards, award{
+			Emoji:    "😅",
+			Title:    "Most 6/6 escapes",
+			UserName: escapist.UserName,
+			Detail:   fmt.Sprintf("%d last-guess solves", escapist.Escapes),
+		})
+	}
+
+	return awards
+}
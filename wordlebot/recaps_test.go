@@ -0,0 +1,90 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestLastWeek(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want recapPeriod
+	}{
+		{
+			name: "on a monday",
+			// Wordle 217 was on a saturday
+			now:  time.Date(2022, time.January, 24, 10, 0, 0, 0, time.UTC),
+			want: recapPeriod{Name: "Weekly", From: 212, To: 218},
+		},
+		{
+			name: "on a sunday",
+			now:  time.Date(2022, time.January, 23, 10, 0, 0, 0, time.UTC),
+			want: recapPeriod{Name: "Weekly", From: 205, To: 211},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lastWeek(tt.now); got != tt.want {
+				t.Fatalf("lastWeek() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastMonth(t *testing.T) {
+	got := lastMonth(time.Date(2022, time.February, 3, 10, 0, 0, 0, time.UTC))
+	// January 1st 2022 was Wordle 196
+	want := recapPeriod{Name: "Monthly", From: 196, To: 226}
+	if got != want {
+		t.Fatalf("lastMonth() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRecapAwards(t *testing.T) {
+	attempts := []db.Attempt{
+		{UserId: "1", UserName: "alice", Day: 10, Attempts: 3, MaxAttempts: 6, Success: true},
+		{UserId: "2", UserName: "bob", Day: 10, Attempts: 6, MaxAttempts: 6, Success: true},
+		{UserId: "1", UserName: "alice", Day: 11, Attempts: 3, MaxAttempts: 6, Success: true},
+		{UserId: "2", UserName: "bob", Day: 11, Attempts: 2, MaxAttempts: 6, Success: true},
+		{UserId: "3", UserName: "carol", Day: 11, Attempts: 6, MaxAttempts: 6, Success: false},
+	}
+	previous := []db.Attempt{
+		{UserId: "1", UserName: "alice", Day: 8, Attempts: 3, MaxAttempts: 6, Success: true},
+		{UserId: "1", UserName: "alice", Day: 9, Attempts: 4, MaxAttempts: 6, Success: true},
+		{UserId: "2", UserName: "bob", Day: 8, Attempts: 6, MaxAttempts: 6, Success: false},
+		{UserId: "2", UserName: "bob", Day: 9, Attempts: 6, MaxAttempts: 6, Success: false},
+	}
+
+	got := recapAwards(attempts, previous, 2, ScoringClassic)
+
+	want := map[string]string{
+		"Champion":         "alice",
+		"Most consistent":  "alice",
+		"Luckiest guess":   "bob",
+		"Most improved":    "bob",
+		"Most 6/6 escapes": "bob",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("recapAwards() returned %d awards, want %d: %+v", len(got), len(want), got)
+	}
+	for _, a := range got {
+		if want[a.Title] != a.UserName {
+			t.Errorf("award %q went to %q, want %q", a.Title, a.UserName, want[a.Title])
+		}
+	}
+}
+
+func TestRecapAwardsWithoutQualifiedPlayers(t *testing.T) {
+	attempts := []db.Attempt{
+		{UserId: "1", UserName: "alice", Day: 10, Attempts: 6, MaxAttempts: 6, Success: false},
+	}
+
+	got := recapAwards(attempts, nil, 2, ScoringClassic)
+	if len(got) != 1 || got[0].Title != "Champion" {
+		t.Fatalf("recapAwards() = %+v, want only the champion award", got)
+	}
+}
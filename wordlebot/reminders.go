@@ -0,0 +1,78 @@
+package wordlebot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultReminderTime is the local time users are reminded at if they don't choose one.
+const defaultReminderTime = "20:00"
+
+// sendReminders reminds users that opted in and didn't post today's wordle in the channel by their reminder time.
+// Each user is reminded at most once per day.
+func (b *WordleBot) sendReminders(now time.Time) {
+	reminders, err := b.repository.Reminders()
+	if err != nil {
+		log.Errorf("getting reminders: %v\n", err)
+		return
+	}
+
+	for _, reminder := range reminders {
+		loc, err := time.LoadLocation(reminder.Timezone)
+		if err != nil {
+			log.Errorf("loading timezone %q of reminder for user %s: %v\n", reminder.Timezone, reminder.UserId, err)
+			continue
+		}
+
+		local := now.In(loc)
+		day := wordle.DayForDate(local)
+		if !clockReached(local, reminder.RemindAt) || reminder.LastRemindedDay >= day {
+			continue
+		}
+
+		posted, err := b.repository.HasAttempt(reminder.ChannelId, reminder.UserId, day)
+		if err != nil {
+			log.Errorf("checking attempt of user %s for reminder: %v\n", reminder.UserId, err)
+			continue
+		}
+
+		if !posted {
+			err = b.sendReminder(reminder, day)
+			if err != nil {
+				log.Errorf("reminding user %s: %v\n", reminder.UserId, err)
+			}
+		}
+
+		err = b.repository.SetLastRemindedDay(reminder.ChannelId, reminder.UserId, day)
+		if err != nil {
+			log.Errorf("saving last reminded day of user %s: %v\n", reminder.UserId, err)
+		}
+	}
+}
+
+func (b *WordleBot) sendReminder(reminder db.Reminder, day int) error {
+	if reminder.Delivery == db.ReminderDeliveryMention {
+		_, err := b.session.ChannelMessageSendComplex(reminder.ChannelId, &discordgo.MessageSend{
+			Content: fmt.Sprintf("⏰ <@%s>, you haven't posted Wordle %d here yet!", reminder.UserId, day),
+			AllowedMentions: &discordgo.MessageAllowedMentions{
+				Users: []string{reminder.UserId},
+			},
+		})
+		return err
+	}
+
+	dm, err := b.session.UserChannelCreate(reminder.UserId)
+	if err != nil {
+		return fmt.Errorf("creating DM channel: %w", err)
+	}
+
+	_, err = b.session.ChannelMessageSend(dm.ID,
+		fmt.Sprintf("⏰ You haven't posted Wordle %d in <#%s> yet! "+
+			"Use `/wordle remind off` in that channel to stop these reminders.", day, reminder.ChannelId))
+	return err
+}
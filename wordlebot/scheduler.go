@@ -0,0 +1,78 @@
+package wordlebot
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const schedulerInterval = time.Minute
+
+// scheduledTask is run by the scheduler on every tick with the current time.
+// Tasks are responsible for remembering what they already did, so they run each action only once.
+type scheduledTask func(now time.Time)
+
+// scheduler runs periodic tasks, like posting daily summaries, once every minute.
+type scheduler struct {
+	tasks  []scheduledTask
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newScheduler(tasks ...scheduledTask) *scheduler {
+	return &scheduler{
+		tasks: tasks,
+	}
+}
+
+func (s *scheduler) Start() {
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+func (s *scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, task := range s.tasks {
+				task(now)
+			}
+		}
+	}
+}
+
+// parseClock parses a time of the day in the HH:MM format.
+func parseClock(clock string) (hour int, minute int, ok bool) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+// clockReached returns true if the local time of the day of now is at or past the given HH:MM clock.
+func clockReached(now time.Time, clock string) bool {
+	hour, minute, ok := parseClock(clock)
+	if !ok {
+		return false
+	}
+	return now.Hour() > hour || (now.Hour() == hour && now.Minute() >= minute)
+}
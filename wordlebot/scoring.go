@@ -0,0 +1,123 @@
+package wordlebot
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+// Scoring schemes of the leaderboard, summaries and recaps of a channel.
+const (
+	// ScoringClassic rewards fewer guesses steeply, and playing at all.
+	ScoringClassic = "classic"
+	// ScoringLinear gives a point per guess left, and nothing for games lost.
+	ScoringLinear = "linear"
+	// ScoringWins counts the games won.
+	ScoringWins = "wins"
+)
+
+var scoringSchemes = []string{ScoringClassic, ScoringLinear, ScoringWins}
+
+// attemptScore is the score of a single attempt, without the decay the leaderboard applies to older days.
+func attemptScore(scoring string, a db.Attempt) float64 {
+	switch scoring {
+	case ScoringLinear:
+		if !a.Success {
+			return 0
+		}
+		return float64(7 - a.Attempts)
+	case ScoringWins:
+		if !a.Success {
+			return 0
+		}
+		return 1
+	default:
+		if !a.Success {
+			return 2
+		}
+		return float64((7-a.Attempts)*(7-a.Attempts) + 2)
+	}
+}
+
+// scoringRule explains how a scoring scheme scores games, fading over the days counted by the leaderboard.
+func scoringRule(scoring string, days int) string {
+	rule := "(7 - guesses)² + 2 per win, 2 per loss"
+	switch scoring {
+	case ScoringLinear:
+		rule = "7 - guesses per win, 0 per loss"
+	case ScoringWins:
+		rule = "1 per win, 0 per loss"
+	}
+	return fmt.Sprintf("Score: %s, fading over %d days", rule, days)
+}
+
+// scoreDecay is the share of the score of a day kept in the leaderboard of today, which counts the given number of
+// days. It fades linearly from the whole score today to nothing the day after the oldest day counted.
+func scoreDecay(day int, today int, days int) float64 {
+	if day > today {
+		return 1
+	}
+	return float64(days-(today-day)) / float64(days)
+}
+
+// leaderboardEntries ranks the players of the attempts of the days counted by the leaderboard of today by their
+// total score, best first. Attempts of other days are ignored.
+func leaderboardEntries(attempts []db.Attempt, today int, settings channelSettings) []db.LeaderboardEntry {
+	var entries []db.LeaderboardEntry
+	byUser := make(map[string]int)
+	guesses := make(map[string]int)
+
+	from := today - settings.LeaderboardDays + 1
+	for _, a := range attempts {
+		if a.Day < from {
+			continue
+		}
+
+		n, ok := byUser[a.UserId]
+		if !ok {
+			n = len(entries)
+			byUser[a.UserId] = n
+			entries = append(entries, db.LeaderboardEntry{UserId: a.UserId})
+		}
+
+		e := &entries[n]
+		e.Username = a.UserName
+		e.TotalScore += scoreDecay(a.Day, today, settings.LeaderboardDays) * attemptScore(settings.Scoring, a)
+		e.Played++
+		guesses[a.UserId] += a.Attempts
+	}
+
+	for n := range entries {
+		entries[n].AvgAttempts = float64(guesses[entries[n].UserId]) / float64(entries[n].Played)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].TotalScore > entries[j].TotalScore
+	})
+
+	return entries
+}
+
+// leaderboard returns the leaderboard of a channel for today, and the attempts it counts. If guildId is set, the
+// leaderboard counts the attempts of every tracked channel of that guild instead, with the settings of the channel.
+func (b *WordleBot) leaderboard(channelId string, guildId string, today int) ([]db.LeaderboardEntry, []db.Attempt,
+	error) {
+	settings := b.channelSettings(channelId)
+
+	// Players ahead of UTC can already be playing tomorrow's wordle, which counts in full.
+	from, to := today-settings.LeaderboardDays+1, today+1
+
+	var attempts []db.Attempt
+	var err error
+	if guildId != "" {
+		attempts, err = b.repository.GuildAttemptsBetweenDays(guildId, from, to)
+	} else {
+		attempts, err = b.repository.AttemptsBetweenDays(channelId, from, to)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting attempts: %w", err)
+	}
+
+	return leaderboardEntries(attempts, today, settings), attempts, nil
+}
@@ -0,0 +1,63 @@
+package wordlebot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestAttemptScore(t *testing.T) {
+	tests := []struct {
+		scoring  string
+		attempts int
+		success  bool
+		want     float64
+	}{
+		{ScoringClassic, 3, true, 18},
+		{ScoringClassic, 6, false, 2},
+		{ScoringLinear, 3, true, 4},
+		{ScoringLinear, 6, false, 0},
+		{ScoringWins, 6, true, 1},
+		{ScoringWins, 6, false, 0},
+	}
+
+	for _, test := range tests {
+		a := db.Attempt{Attempts: test.attempts, Success: test.success}
+		if got := attemptScore(test.scoring, a); got != test.want {
+			t.Errorf("attemptScore(%q, %d, %v) = %v, want %v", test.scoring, test.attempts, test.success, got,
+				test.want)
+		}
+	}
+}
+
+func TestLeaderboardEntries(t *testing.T) {
+	settings := newChannelSettings(map[string]string{settingLeaderboardDays: "10"})
+	attempts := []db.Attempt{
+		{UserId: "1", UserName: "alice", Day: 90, Attempts: 2, Success: true},
+		{UserId: "1", UserName: "alice", Day: 91, Attempts: 3, Success: true},
+		{UserId: "2", UserName: "bob", Day: 95, Attempts: 4, Success: true},
+		{UserId: "2", UserName: "bob", Day: 100, Attempts: 6, Success: false},
+		{UserId: "3", UserName: "carol", Day: 101, Attempts: 1, Success: true},
+	}
+
+	entries := leaderboardEntries(attempts, 100, settings)
+
+	// alice's day 90 is out of the window, day 91 keeps 1/10 of its 18 points, bob keeps half of 11 points and
+	// the 2 points of today, and carol's game of tomorrow counts in full.
+	want := []db.LeaderboardEntry{
+		{UserId: "3", Username: "carol", TotalScore: 38, AvgAttempts: 1, Played: 1},
+		{UserId: "2", Username: "bob", TotalScore: 7.5, AvgAttempts: 5, Played: 2},
+		{UserId: "1", Username: "alice", TotalScore: 1.8, AvgAttempts: 3, Played: 1},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("leaderboardEntries() = %+v, want %+v", entries, want)
+	}
+	for n := range want {
+		got := entries[n]
+		if got.UserId != want[n].UserId || got.Username != want[n].Username || got.Played != want[n].Played ||
+			math.Abs(got.TotalScore-want[n].TotalScore) > 1e-9 || got.AvgAttempts != want[n].AvgAttempts {
+			t.Errorf("leaderboardEntries()[%d] = %+v, want %+v", n, got, want[n])
+		}
+	}
+}
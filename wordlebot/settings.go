@@ -0,0 +1,363 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Names of the settings of a channel.
+const (
+	settingReactions       = "reactions"
+	settingTimezone        = "timezone"
+	settingSummaryTime     = "summary-time"
+	settingWeeklyRecap     = "weekly-recap"
+	settingMonthlyRecap    = "monthly-recap"
+	settingSpoilerGuard    = "spoiler-guard"
+	settingPublicResponses = "public-responses"
+	settingLeaderboardDays = "leaderboard-days"
+	settingScoring         = "scoring"
+	settingTeamScoring     = "team-scoring"
+	settingTeamTop         = "team-top"
+)
+
+const (
+	// maxLeaderboardDays is the longest the leaderboard can look back.
+	maxLeaderboardDays = 365
+	// maxTeamTop is the most members per team the top scoring method can count.
+	maxTeamTop = 25
+)
+
+// channelSettings are the settings of a tracked channel.
+type channelSettings struct {
+	ReactionSet string
+	Timezone    string
+	// SummaryTime is the local time, in HH:MM format, at which the daily summary is posted.
+	// Empty if daily summaries are disabled.
+	SummaryTime  string
+	WeeklyRecap  bool
+	MonthlyRecap bool
+	// SpoilerGuard is what to do with messages revealing the answer of the day.
+	SpoilerGuard string
+	// PublicResponses is whether read-only commands are answered publicly when they don't say otherwise.
+	PublicResponses bool
+	// LeaderboardDays is the number of days counted for the leaderboard, over which scores fade.
+	LeaderboardDays int
+	Scoring         string
+	// TeamScoring is how the scores of the members of a team add up to the score of the team, and TeamTop the
+	// number of members counted by the top method.
+	TeamScoring string
+	TeamTop     int
+
+	// values are the settings by name, in the form they're stored.
+	values map[string]string
+}
+
+// Value returns a setting by name, in the form it's stored.
+func (c channelSettings) Value(name string) string {
+	return c.values[name]
+}
+
+// Location returns the timezone of the channel, or UTC if it can't be loaded.
+func (c channelSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// setting is a setting of a channel. Settings are stored as text, in the form normalize returns.
+type setting struct {
+	Name        string
+	Description string
+	Default     string
+	// Choices are the only values accepted, if any.
+	Choices []string
+	// Suggestions are common values of settings that accept more than a few choices.
+	Suggestions []string
+	// normalize validates a value given by a user and returns it the way it's stored.
+	normalize func(value string) (string, error)
+	// apply sets a normalized value in the settings of a channel.
+	apply func(c *channelSettings, value string)
+}
+
+var settingDefinitions = []setting{
+	{
+		Name:        settingReactions,
+		Description: "Reactions to wordle copy/pastes",
+		Default:     ReactionSetClassic,
+		Choices:     reactionSets,
+		apply:       func(c *channelSettings, value string) { c.ReactionSet = value },
+	},
+	{
+		Name:        settingTimezone,
+		Description: "Timezone of the daily summary, recaps and day of the channel",
+		Default:     "UTC",
+		Suggestions: []string{"UTC", "Europe/London", "Europe/Lisbon", "America/New_York", "America/Los_Angeles"},
+		normalize:   normalizeTimezone,
+		apply:       func(c *channelSettings, value string) { c.Timezone = value },
+	},
+	{
+		Name:        settingSummaryTime,
+		Description: "Local time of the daily summary, HH:MM or off",
+		Default:     "off",
+		Suggestions: []string{"off", "08:00", "09:00", "12:00", "18:00"},
+		normalize:   normalizeSummaryTime,
+		apply: func(c *channelSettings, value string) {
+			c.SummaryTime = value
+			if value == "off" {
+				c.SummaryTime = ""
+			}
+		},
+	},
+	{
+		Name:        settingWeeklyRecap,
+		Description: "Post a recap of each week",
+		Default:     "false",
+		Suggestions: []string{"true", "false"},
+		normalize:   normalizeBool,
+		apply:       func(c *channelSettings, value string) { c.WeeklyRecap = value == "true" },
+	},
+	{
+		Name:        settingMonthlyRecap,
+		Description: "Post a recap of each month",
+		Default:     "false",
+		Suggestions: []string{"true", "false"},
+		normalize:   normalizeBool,
+		apply:       func(c *channelSettings, value string) { c.MonthlyRecap = value == "true" },
+	},
+	{
+		Name:        settingSpoilerGuard,
+		Description: "What to do with messages revealing the answer of the day",
+		Default:     SpoilerGuardOff,
+		Choices:     spoilerGuardModes,
+		apply:       func(c *channelSettings, value string) { c.SpoilerGuard = value },
+	},
+	{
+		Name:        settingPublicResponses,
+		Description: "Show read-only commands to everyone unless they ask otherwise",
+		Default:     "true",
+		Suggestions: []string{"true", "false"},
+		normalize:   normalizeBool,
+		apply:       func(c *channelSettings, value string) { c.PublicResponses = value == "true" },
+	},
+	{
+		Name:        settingLeaderboardDays,
+		Description: fmt.Sprintf("Days counted by the leaderboard, 1 to %d", maxLeaderboardDays),
+		Default:     "30",
+		Suggestions: []string{"7", "30", "90", "365"},
+		normalize:   normalizeLeaderboardDays,
+		apply: func(c *channelSettings, value string) {
+			c.LeaderboardDays, _ = strconv.Atoi(value)
+		},
+	},
+	{
+		Name:        settingScoring,
+		Description: "How games are scored",
+		Default:     ScoringClassic,
+		Choices:     scoringSchemes,
+		apply:       func(c *channelSettings, value string) { c.Scoring = value },
+	},
+	{
+		Name:        settingTeamScoring,
+		Description: "How the scores of the members of a team add up",
+		Default:     TeamScoringSum,
+		Choices:     teamScoringMethods,
+		apply:       func(c *channelSettings, value string) { c.TeamScoring = value },
+	},
+	{
+		Name:        settingTeamTop,
+		Description: fmt.Sprintf("Members per team the top team scoring counts, 1 to %d", maxTeamTop),
+		Default:     "3",
+		Suggestions: []string{"3", "5", "10"},
+		normalize:   normalizeTeamTop,
+		apply: func(c *channelSettings, value string) {
+			c.TeamTop, _ = strconv.Atoi(value)
+		},
+	},
+}
+
+// findSetting returns the setting with the given name.
+func findSetting(name string) (setting, bool) {
+	for _, s := range settingDefinitions {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// Normalize validates a value given by a user for the setting and returns it the way it's stored. The errors
+// explain to the user what values are valid.
+func (s setting) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	if len(s.Choices) > 0 {
+		value = strings.ToLower(value)
+		if !sliceHasString(s.Choices, value) {
+			return "", fmt.Errorf("%q is not a valid value for `%s`. Use one of `%s`.", value, s.Name,
+				strings.Join(s.Choices, "`, `"))
+		}
+	}
+
+	if s.normalize == nil {
+		return value, nil
+	}
+	return s.normalize(value)
+}
+
+func normalizeTimezone(value string) (string, error) {
+	if _, err := time.LoadLocation(value); value == "" || err != nil {
+		return "", fmt.Errorf("%q is not a valid timezone. Use a name like `Europe/Lisbon` or `America/New_York`.",
+			value)
+	}
+	return value, nil
+}
+
+func normalizeSummaryTime(value string) (string, error) {
+	if value == "" || strings.EqualFold(value, "off") {
+		return "off", nil
+	}
+	if _, _, ok := parseClock(value); !ok {
+		return "", fmt.Errorf("%q is not a valid time. Use the HH:MM format, e.g. `09:30`, or `off`.", value)
+	}
+	return value, nil
+}
+
+func normalizeBool(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "true", "on", "yes":
+		return "true", nil
+	case "false", "off", "no":
+		return "false", nil
+	}
+	return "", fmt.Errorf("%q is not a valid value. Use `true` or `false`.", value)
+}
+
+func normalizeLeaderboardDays(value string) (string, error) {
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > maxLeaderboardDays {
+		return "", fmt.Errorf("%q is not a valid number of days. Use a number from 1 to %d.", value,
+			maxLeaderboardDays)
+	}
+	return strconv.Itoa(days), nil
+}
+
+func normalizeTeamTop(value string) (string, error) {
+	top, err := strconv.Atoi(value)
+	if err != nil || top < 1 || top > maxTeamTop {
+		return "", fmt.Errorf("%q is not a valid number of members. Use a number from 1 to %d.", value, maxTeamTop)
+	}
+	return strconv.Itoa(top), nil
+}
+
+// newChannelSettings builds the settings of a channel from the values stored for it, by name. Settings without a
+// stored value, or with one that is no longer valid, take their default.
+func newChannelSettings(values map[string]string) channelSettings {
+	c := channelSettings{values: make(map[string]string, len(settingDefinitions))}
+	for _, s := range settingDefinitions {
+		value, ok := values[s.Name]
+		if ok {
+			normalized, err := s.Normalize(value)
+			if err != nil {
+				log.Errorf("ignoring invalid value %q of setting %s: %v\n", value, s.Name, err)
+				ok = false
+			}
+			value = normalized
+		}
+		if !ok {
+			value = s.Default
+		}
+		c.values[s.Name] = value
+		s.apply(&c, value)
+	}
+	return c
+}
+
+// settingsCache keeps the settings of the channels in memory, loading the settings of a channel on its first use.
+type settingsCache struct {
+	load func(channelId string) (map[string]string, error)
+
+	mu       sync.Mutex
+	channels map[string]channelSettings
+}
+
+func newSettingsCache(load func(channelId string) (map[string]string, error)) *settingsCache {
+	return &settingsCache{
+		load:     load,
+		channels: make(map[string]channelSettings),
+	}
+}
+
+// Get returns the settings of a channel.
+func (c *settingsCache) Get(channelId string) (channelSettings, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if settings, ok := c.channels[channelId]; ok {
+		return settings, nil
+	}
+
+	values, err := c.load(channelId)
+	if err != nil {
+		return channelSettings{}, fmt.Errorf("loading settings of channel %s: %w", channelId, err)
+	}
+
+	settings := newChannelSettings(values)
+	c.channels[channelId] = settings
+	return settings, nil
+}
+
+// Invalidate forgets the settings of a channel, so they are loaded again on their next use.
+func (c *settingsCache) Invalidate(channelId string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.channels, channelId)
+}
+
+// channelSettings returns the settings of a channel, or the defaults if they can't be loaded.
+func (b *WordleBot) channelSettings(channelId string) channelSettings {
+	settings, err := b.settings.Get(channelId)
+	if err != nil {
+		log.Errorf("getting settings of channel %s, using the defaults: %v\n", channelId, err)
+		return newChannelSettings(nil)
+	}
+	return settings
+}
+
+// normalizeSettings validates the given values of settings, by name, and returns them the way they're stored. The
+// errors explain to the user what values are valid.
+func normalizeSettings(values map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(values))
+	for name, value := range values {
+		s, ok := findSetting(name)
+		if !ok {
+			return nil, fmt.Errorf("There's no setting called %q.", name)
+		}
+
+		var err error
+		normalized[name], err = s.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return normalized, nil
+}
+
+// setChannelSettings stores the given normalized values of settings of a channel, by name, and forgets the cached
+// settings of the channel.
+func (b *WordleBot) setChannelSettings(channelId string, values map[string]string) error {
+	defer b.settings.Invalidate(channelId)
+
+	err := b.repository.SetChannelSettings(channelId, values)
+	if err != nil {
+		return fmt.Errorf("storing settings: %w", err)
+	}
+
+	return nil
+}
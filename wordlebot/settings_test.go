@@ -0,0 +1,118 @@
+package wordlebot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewChannelSettingsDefaults(t *testing.T) {
+	got := newChannelSettings(nil)
+
+	if got.ReactionSet != ReactionSetClassic || got.Timezone != "UTC" || got.SummaryTime != "" ||
+		got.WeeklyRecap || got.MonthlyRecap || got.SpoilerGuard != SpoilerGuardOff || !got.PublicResponses ||
+		got.LeaderboardDays != 30 || got.Scoring != ScoringClassic {
+		t.Errorf("newChannelSettings(nil) = %+v, want the defaults", got)
+	}
+
+	for _, s := range settingDefinitions {
+		if got.Value(s.Name) != s.Default {
+			t.Errorf("newChannelSettings(nil).Value(%q) = %q, want %q", s.Name, got.Value(s.Name), s.Default)
+		}
+	}
+}
+
+func TestNewChannelSettings(t *testing.T) {
+	got := newChannelSettings(map[string]string{
+		settingReactions:       ReactionSetResults,
+		settingSummaryTime:     "09:30",
+		settingWeeklyRecap:     "true",
+		settingPublicResponses: "false",
+		settingLeaderboardDays: "7",
+		settingScoring:         ScoringWins,
+		// Invalid values fall back to the default
+		settingTimezone: "Mars/Olympus_Mons",
+	})
+
+	if got.ReactionSet != ReactionSetResults || got.SummaryTime != "09:30" || !got.WeeklyRecap ||
+		got.PublicResponses || got.LeaderboardDays != 7 || got.Scoring != ScoringWins || got.Timezone != "UTC" {
+		t.Errorf("newChannelSettings() = %+v", got)
+	}
+}
+
+func TestNormalizeSettings(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		ok    bool
+	}{
+		{settingReactions, " Results ", ReactionSetResults, true},
+		{settingReactions, "party", "", false},
+		{settingTimezone, "Europe/Lisbon", "Europe/Lisbon", true},
+		{settingTimezone, "", "", false},
+		{settingSummaryTime, "OFF", "off", true},
+		{settingSummaryTime, "25:00", "", false},
+		{settingWeeklyRecap, "yes", "true", true},
+		{settingWeeklyRecap, "maybe", "", false},
+		{settingLeaderboardDays, "007", "7", true},
+		{settingLeaderboardDays, "0", "", false},
+		{settingLeaderboardDays, "366", "", false},
+		{"colour", "blue", "", false},
+	}
+
+	for _, test := range tests {
+		got, err := normalizeSettings(map[string]string{test.name: test.value})
+		if (err == nil) != test.ok {
+			t.Errorf("normalizeSettings(%s: %q) error = %v, want ok %v", test.name, test.value, err, test.ok)
+			continue
+		}
+		if test.ok && got[test.name] != test.want {
+			t.Errorf("normalizeSettings(%s: %q) = %q, want %q", test.name, test.value, got[test.name], test.want)
+		}
+	}
+}
+
+func TestSettingsCache(t *testing.T) {
+	stored := map[string]string{settingScoring: ScoringLinear}
+	loads := 0
+	cache := newSettingsCache(func(channelId string) (map[string]string, error) {
+		loads++
+		if channelId == "broken" {
+			return nil, errors.New("connection lost")
+		}
+		return stored, nil
+	})
+
+	for n := 0; n < 2; n++ {
+		settings, err := cache.Get("1")
+		if err != nil || settings.Scoring != ScoringLinear {
+			t.Fatalf("Get() = %+v, %v, want linear scoring", settings, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("settings loaded %d times, want once", loads)
+	}
+
+	stored = map[string]string{settingScoring: ScoringWins}
+	cache.Invalidate("1")
+	settings, err := cache.Get("1")
+	if err != nil || settings.Scoring != ScoringWins {
+		t.Errorf("Get() after Invalidate() = %+v, %v, want wins scoring", settings, err)
+	}
+
+	if _, err := cache.Get("broken"); err == nil {
+		t.Errorf("Get() of a channel whose settings can't be loaded returned no error")
+	}
+}
+
+func TestSettingValueChoices(t *testing.T) {
+	choices := settingValueChoices(settingScoring, "li")
+	if len(choices) != 1 || choices[0].Value != ScoringLinear {
+		t.Errorf("settingValueChoices(scoring, \"li\") = %+v, want linear", choices)
+	}
+
+	choices = settingValueChoices(settingTimezone, "Asia/Tokyo")
+	if len(choices) != 1 || choices[0].Value != "Asia/Tokyo" {
+		t.Errorf("settingValueChoices(timezone, \"Asia/Tokyo\") = %+v, want what was typed", choices)
+	}
+}
@@ -0,0 +1,176 @@
+package wordlebot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// Spoiler guard modes, for messages in tracked channels that reveal the answer of the day.
+const (
+	SpoilerGuardOff = "off"
+	// SpoilerGuardDelete deletes the message.
+	SpoilerGuardDelete = "delete"
+	// SpoilerGuardSpoiler deletes the message and posts it again with the answer in spoiler tags.
+	SpoilerGuardSpoiler = "spoiler"
+	// SpoilerGuardWarn replies to the message asking the author to hide the answer.
+	SpoilerGuardWarn = "warn"
+)
+
+var spoilerGuardModes = []string{SpoilerGuardOff, SpoilerGuardDelete, SpoilerGuardSpoiler, SpoilerGuardWarn}
+
+// revealedAnswer returns the answer revealed by a message, if any. Only the answer of the current day in the timezone
+// of the channel and the answer of the day of the copy/paste in the message, if there's one, are considered.
+func revealedAnswer(m *discordgo.Message, settings channelSettings) (string, bool) {
+	days := []int{wordle.DayForDate(time.Now().In(settings.Location()))}
+	if attempt, ok := wordle.ParseCopyPaste(m.Content); ok {
+		days = append(days, attempt.Day)
+	}
+
+	for _, day := range days {
+		answer, ok := wordle.Answer(day)
+		if ok && wordle.RevealsWord(m.Content, answer) {
+			return answer, true
+		}
+	}
+
+	return "", false
+}
+
+// spoilerFollowUpWindow is how long after posting a copy/paste the messages of a player are checked for spoilers, as
+// players often comment on their result right after posting it.
+const spoilerFollowUpWindow = 10 * time.Minute
+
+// recentCopyPastes remembers when each player last posted a copy/paste in each channel.
+type recentCopyPastes struct {
+	mu    sync.Mutex
+	posts map[string]time.Time
+}
+
+func newRecentCopyPastes() *recentCopyPastes {
+	return &recentCopyPastes{posts: make(map[string]time.Time)}
+}
+
+// Add remembers that a player posted a copy/paste in a channel at the given time, forgetting copy/pastes old enough
+// to no longer matter.
+func (r *recentCopyPastes) Add(channelId string, userId string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, posted := range r.posts {
+		if at.Sub(posted) >= spoilerFollowUpWindow {
+			delete(r.posts, key)
+		}
+	}
+	r.posts[channelId+"/"+userId] = at
+}
+
+// Follows tells whether a message a player posted in a channel at the given time follows a copy/paste of theirs.
+func (r *recentCopyPastes) Follows(channelId string, userId string, at time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	posted, ok := r.posts[channelId+"/"+userId]
+	return ok && at.Sub(posted) < spoilerFollowUpWindow
+}
+
+// guardSpoilers acts on a new message revealing the answer of the day according to the spoiler guard mode of the
+// channel. Only copy/pastes and the messages that follow a copy/paste of the same author are checked, so the answer
+// used as an ordinary word in chat is left alone. Returns true if the message was fully handled and must not be
+// processed further.
+func (b *WordleBot) guardSpoilers(m *discordgo.Message, settings channelSettings) bool {
+	now := time.Now()
+	if _, ok := wordle.ParseCopyPaste(m.Content); ok {
+		b.copyPastes.Add(m.ChannelID, m.Author.ID, now)
+	} else if !b.copyPastes.Follows(m.ChannelID, m.Author.ID, now) {
+		return false
+	}
+
+	answer, revealed := revealedAnswer(m, settings)
+	if !revealed {
+		return false
+	}
+
+	switch settings.SpoilerGuard {
+	case SpoilerGuardDelete:
+		return b.deleteSpoiler(m, settings)
+
+	case SpoilerGuardSpoiler:
+		content := fmt.Sprintf("🙊 <@%s> said:\n%s", m.Author.ID, wordle.HideWord(m.Content, answer))
+		if utf8.RuneCountInString(content) > maxMessageLength {
+			// The repost wouldn't fit in a message.
+			return b.deleteSpoiler(m, settings)
+		}
+
+		repost, err := b.postForPlayer(m.ChannelID, m.Author, &discordgo.MessageSend{
+			Content:         content,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		if err != nil {
+			log.Errorf("failed to repost spoiler: %v\n", err)
+			return false
+		}
+
+		// The copy/paste, if any, now lives in the repost but still belongs to the original author.
+		repost.Timestamp = m.Timestamp
+		b.recordLiveMessage(repost, settings)
+
+		err = b.session.ChannelMessageDelete(m.ChannelID, m.ID)
+		if err != nil {
+			log.Errorf("failed to delete spoiler: %v\n", err)
+		}
+		return true
+
+	case SpoilerGuardWarn:
+		_, err := b.session.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+			Content:   "⚠️ Careful, that's today's answer! Please wrap it in spoiler tags, ||like this||.",
+			Reference: m.Reference(),
+		})
+		if err != nil {
+			log.Errorf("failed to warn about spoiler: %v\n", err)
+		}
+	}
+
+	return false
+}
+
+// deleteSpoiler deletes a message revealing the answer of the day and lets its author know. The copy/paste in the
+// message, if any, is still recorded for its author, on the notice, as the attempts of deleted messages are removed.
+// Returns true if the message was deleted.
+func (b *WordleBot) deleteSpoiler(m *discordgo.Message, settings channelSettings) bool {
+	err := b.session.ChannelMessageDelete(m.ChannelID, m.ID)
+	if err != nil {
+		log.Errorf("failed to delete spoiler: %v\n", err)
+		return false
+	}
+
+	_, isCopyPaste := wordle.ParseCopyPaste(m.Content)
+	recorded := ""
+	if isCopyPaste {
+		recorded = "Your result was still recorded. "
+	}
+
+	notice, err := b.session.ChannelMessageSendComplex(m.ChannelID, &discordgo.MessageSend{
+		Content: fmt.Sprintf("🙊 <@%s>, your message was removed because it revealed today's answer. %s"+
+			"Wrap it in spoiler tags, ||like this||, next time!", m.Author.ID, recorded),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Users: []string{m.Author.ID},
+		},
+	})
+	if err != nil {
+		log.Errorf("failed to notify about deleted spoiler: %v\n", err)
+		return true
+	}
+
+	if isCopyPaste {
+		recorded := *m
+		recorded.ID = notice.ID
+		b.recordLiveMessage(&recorded, settings)
+	}
+	return true
+}
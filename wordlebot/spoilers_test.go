@@ -0,0 +1,35 @@
+package wordlebot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecentCopyPastes(t *testing.T) {
+	posted := time.Date(2022, 9, 14, 12, 0, 0, 0, time.UTC)
+	recent := newRecentCopyPastes()
+	recent.Add("c", "alice", posted)
+
+	tests := []struct {
+		channelId string
+		userId    string
+		at        time.Time
+		want      bool
+	}{
+		{"c", "alice", posted.Add(time.Minute), true},
+		{"c", "alice", posted.Add(spoilerFollowUpWindow), false},
+		{"c", "bob", posted.Add(time.Minute), false},
+		{"other", "alice", posted.Add(time.Minute), false},
+	}
+
+	for _, test := range tests {
+		if got := recent.Follows(test.channelId, test.userId, test.at); got != test.want {
+			t.Errorf("Follows(%q, %q, %v) = %v, want %v", test.channelId, test.userId, test.at, got, test.want)
+		}
+	}
+
+	recent.Add("c", "bob", posted.Add(spoilerFollowUpWindow))
+	if _, ok := recent.posts["c/alice"]; ok {
+		t.Errorf("Add() kept a copy/paste older than the follow-up window")
+	}
+}
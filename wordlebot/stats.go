@@ -0,0 +1,136 @@
+package wordlebot
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/game"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// playerStats summarizes the games of a player.
+type playerStats struct {
+	Played int
+	Won    int
+	// CurrentStreak and MaxStreak are the number of consecutive games won.
+	CurrentStreak int
+	MaxStreak     int
+	// Distribution has the number of games won with each number of guesses, starting with 1.
+	Distribution [game.DefaultMaxGuesses]int
+}
+
+func (p *playerStats) addWin(attempts int) {
+	if attempts >= 1 && attempts <= len(p.Distribution) {
+		p.Distribution[attempts-1]++
+	}
+}
+
+// AvgGuesses returns the average number of guesses of the games won.
+func (p playerStats) AvgGuesses() float64 {
+	var games, guesses int
+	for n, count := range p.Distribution {
+		games += count
+		guesses += (n + 1) * count
+	}
+	if games == 0 {
+		return 0
+	}
+	return float64(guesses) / float64(games)
+}
+
+// newDailyStats computes the stats of the attempts of a player in a channel, in order of day. Streaks count
+// consecutive days won, and the current streak is only kept until the day after the last day won.
+func newDailyStats(attempts []db.Attempt, today int) playerStats {
+	var stats playerStats
+
+	streak, lastWon := 0, -1
+	for _, a := range attempts {
+		stats.Played++
+
+		if !a.Success {
+			streak = 0
+			continue
+		}
+
+		stats.Won++
+		if streak > 0 && a.Day == lastWon+1 {
+			streak++
+		} else {
+			streak = 1
+		}
+		lastWon = a.Day
+		if streak > stats.MaxStreak {
+			stats.MaxStreak = streak
+		}
+		stats.addWin(a.Attempts)
+	}
+
+	if lastWon >= today-1 {
+		stats.CurrentStreak = streak
+	}
+
+	return stats
+}
+
+func (b *WordleBot) HandleStatsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := interactionUser(i)
+	if opt, ok := subCommandOptions(i)["user"]; ok {
+		var err error
+		user, err = b.playerOption(s, i.ChannelID, opt)
+		if err != nil {
+			log.Errorf("Error handling stats interaction: %v", err)
+			respondProblem(s, i, b.responseFlags(i))
+			return err
+		}
+		if user == nil {
+			return respondEphemeralEmbed(s, i, unknownPlayerEmbed(opt))
+		}
+	}
+
+	return b.respondStats(s, i, user)
+}
+
+// HandleUserStatsInteraction shows the stats of the member a user command was used on.
+func (b *WordleBot) HandleUserStatsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	data := i.ApplicationCommandData()
+
+	var user *discordgo.User
+	if data.Resolved != nil {
+		user = data.Resolved.Users[data.TargetID]
+	}
+	if user == nil {
+		return fmt.Errorf("target user %s of user command not resolved", data.TargetID)
+	}
+
+	return b.respondStats(s, i, user)
+}
+
+// respondStats responds to an interaction with the stats of a user in the channel of the interaction.
+func (b *WordleBot) respondStats(s *discordgo.Session, i *discordgo.InteractionCreate, user *discordgo.User) error {
+	flags := b.responseFlags(i)
+
+	attempts, err := b.repository.UserAttempts(i.ChannelID, user.ID)
+	if err != nil {
+		log.Errorf("Error handling stats interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	if len(attempts) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", fmt.Sprintf("%s hasn't posted any wordle in this channel "+
+			"yet.", user.Username), colorNeutral), flags)
+	}
+
+	today := wordle.DayForDate(time.Now().UTC())
+
+	embed := statsEmbed(fmt.Sprintf("Stats of %s", user.Username), newDailyStats(attempts, today))
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: fmt.Sprintf("Wordle %d to %d · Streaks count consecutive days won", attempts[0].Day,
+			attempts[len(attempts)-1].Day),
+	}
+
+	return respondEmbedWithFlags(s, i, embed, flags)
+}
@@ -0,0 +1,52 @@
+package wordlebot
+
+import (
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestDailyStats(t *testing.T) {
+	attempts := []db.Attempt{
+		{Day: 200, Attempts: 4, Success: true},
+		{Day: 201, Attempts: 3, Success: true},
+		{Day: 202, Attempts: 5, Success: true},
+		{Day: 204, Attempts: 6, Success: false},
+		{Day: 205, Attempts: 2, Success: true},
+		{Day: 206, Attempts: 4, Success: true},
+	}
+
+	got := newDailyStats(attempts, 207)
+	want := playerStats{
+		Played:        6,
+		Won:           5,
+		CurrentStreak: 2,
+		MaxStreak:     3,
+		Distribution:  [6]int{0, 1, 1, 2, 1, 0},
+	}
+
+	if got != want {
+		t.Fatalf("newDailyStats() = %+v, want %+v", got, want)
+	}
+
+	if avg := got.AvgGuesses(); avg != 3.6 {
+		t.Fatalf("AvgGuesses() = %v, want 3.6", avg)
+	}
+}
+
+func TestDailyStatsBrokenStreak(t *testing.T) {
+	attempts := []db.Attempt{
+		{Day: 200, Attempts: 4, Success: true},
+		{Day: 201, Attempts: 3, Success: true},
+	}
+
+	// Days without a game break the streak, just like losing
+	if got := newDailyStats(attempts, 203).CurrentStreak; got != 0 {
+		t.Fatalf("newDailyStats().CurrentStreak = %d, want 0", got)
+	}
+
+	// The streak is kept while today's wordle wasn't posted yet
+	if got := newDailyStats(attempts, 202).CurrentStreak; got != 2 {
+		t.Fatalf("newDailyStats().CurrentStreak = %d, want 2", got)
+	}
+}
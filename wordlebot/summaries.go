@@ -0,0 +1,194 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	summaryLeaderboardSize = 5
+	// summaryNamesLength is the most characters taken by each list of players of a summary.
+	summaryNamesLength = 400
+	// maxMessageLength is the most characters Discord accepts in a message.
+	maxMessageLength = 2000
+)
+
+// postDailySummaries posts the summary of yesterday's wordle to every channel whose summary time has been reached
+// and that didn't get that summary yet.
+func (b *WordleBot) postDailySummaries(now time.Time) {
+	channels, err := b.repository.TrackedChannels()
+	if err != nil {
+		log.Errorf("getting channels for daily summaries: %v\n", err)
+		return
+	}
+
+	for _, channel := range channels {
+		settings, err := b.settings.Get(channel.ChannelId)
+		if err != nil {
+			log.Errorf("getting settings of channel %s for daily summaries: %v\n", channel.ChannelId, err)
+			continue
+		}
+
+		if settings.SummaryTime == "" {
+			continue
+		}
+
+		local := now.In(settings.Location())
+		day := wordle.DayForDate(local) - 1
+		if !clockReached(local, settings.SummaryTime) || channel.LastSummaryDay >= day {
+			continue
+		}
+
+		err = b.postDailySummary(channel.ChannelId, channel.GuildId, day)
+		if err != nil {
+			log.Errorf("posting daily summary of day %d to channel %s: %v\n", day, channel.ChannelId, err)
+			continue
+		}
+
+		err = b.repository.SetLastSummaryDay(channel.ChannelId, day)
+		if err != nil {
+			log.Errorf("saving last summary day of channel %s: %v\n", channel.ChannelId, err)
+		}
+	}
+}
+
+func (b *WordleBot) postDailySummary(channelId string, guildId string, day int) error {
+	attempts, err := b.repository.AttemptsForDay(channelId, day)
+	if err != nil {
+		return fmt.Errorf("getting attempts: %w", err)
+	}
+
+	today := wordle.DayForDate(time.Now().UTC())
+	entries, _, err := b.leaderboard(channelId, "", today)
+	if err != nil {
+		return fmt.Errorf("getting leaderboard: %w", err)
+	}
+
+	// Teams are roles, so only channels of a guild can have them.
+	var teams []teamStanding
+	if guildId != "" {
+		settings := b.channelSettings(channelId)
+		teams, err = b.teamStandings(channelId, guildId, today, settings.TeamScoring, settings.TeamTop)
+		if err != nil {
+			log.Errorf("getting team standings of channel %s, posting the summary without them: %v\n", channelId, err)
+		}
+	}
+
+	_, err = b.session.ChannelMessageSend(channelId, dailySummary(day, attempts, entries, teams))
+	if err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+
+	return nil
+}
+
+// dailySummary formats the results of a day, the top of the leaderboard and the standings of the teams, if any.
+// Attempts must be sorted from best to worst.
+func dailySummary(day int, attempts []db.Attempt, entries []db.LeaderboardEntry, teams []teamStanding) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "📰 **Wordle %d summary**\n", day)
+
+	if len(attempts) == 0 {
+		builder.WriteString("Nobody played yesterday's wordle 😢\n")
+	} else {
+		var solved, failed []string
+		for _, a := range attempts {
+			if a.Success {
+				solved = append(solved, fmt.Sprintf("%s (%d/%d%s)", a.UserName, a.Attempts, a.MaxAttempts, hardModeMark(a.HardMode)))
+			} else {
+				failed = append(failed, a.UserName)
+			}
+		}
+
+		fmt.Fprintf(&builder, "👥 %d played\n", len(attempts))
+
+		best := attempts[0]
+		if best.Success {
+			var bestPlayers []string
+			for _, a := range attempts {
+				if a.Success && a.Attempts == best.Attempts {
+					bestPlayers = append(bestPlayers, a.UserName)
+				}
+			}
+			fmt.Fprintf(&builder, "🏆 Best score: %d/%d by %s\n", best.Attempts, best.MaxAttempts,
+				joinNames(bestPlayers, summaryNamesLength))
+		}
+
+		if len(solved) > 0 {
+			fmt.Fprintf(&builder, "✅ Solved: %s\n", joinNames(solved, summaryNamesLength))
+		}
+		if len(failed) > 0 {
+			fmt.Fprintf(&builder, "💀 Failed: %s\n", joinNames(failed, summaryNamesLength))
+		}
+	}
+
+	if len(entries) > 0 {
+		builder.WriteString("\n**Leaderboard**\n")
+		for rank, entry := range entries {
+			if rank == summaryLeaderboardSize {
+				break
+			}
+			fmt.Fprintf(&builder, "%d. %s — %.2f\n", rank+1, entry.Username, entry.TotalScore)
+		}
+	}
+
+	if len(teams) > 0 {
+		builder.WriteString("\n**Teams**\n")
+		builder.WriteString(teamStandingsText(teams))
+	}
+
+	return truncateLines(builder.String(), maxMessageLength)
+}
+
+// joinNames joins as many names as fit in the given number of characters, saying how many more were left out.
+// The first name is always included.
+func joinNames(names []string, maxLength int) string {
+	var builder strings.Builder
+	length := 0
+	for n, name := range names {
+		if n > 0 {
+			// Unless this is the last name, there must be room left to say how many more there are.
+			needed := len(", ") + utf8.RuneCountInString(name)
+			if n < len(names)-1 {
+				needed += utf8.RuneCountInString(fmt.Sprintf(" …and %d more", len(names)-n-1))
+			}
+			if length+needed > maxLength {
+				fmt.Fprintf(&builder, " …and %d more", len(names)-n)
+				break
+			}
+			builder.WriteString(", ")
+			length += len(", ")
+		}
+		builder.WriteString(name)
+		length += utf8.RuneCountInString(name)
+	}
+	return builder.String()
+}
+
+// truncateLines drops the last lines of a text until it fits in the given number of characters, ending it with an
+// ellipsis if any were dropped.
+func truncateLines(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	for len(lines) > 0 && utf8.RuneCountInString(strings.Join(lines, ""))+utf8.RuneCountInString("…") > maxLength {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "") + "…"
+}
+
+func hardModeMark(hardMode bool) string {
+	if hardMode {
+		return "*"
+	}
+	return ""
+}
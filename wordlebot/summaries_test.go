@@ -0,0 +1,86 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestDailySummary(t *testing.T) {
+	attempts := []db.Attempt{
+		{UserName: "alice", Attempts: 3, MaxAttempts: 6, Success: true, HardMode: true},
+		{UserName: "bob", Attempts: 3, MaxAttempts: 6, Success: true},
+		{UserName: "carol", Attempts: 6, MaxAttempts: 6, Success: false},
+	}
+	entries := []db.LeaderboardEntry{
+		{Username: "bob", TotalScore: 40},
+		{Username: "alice", TotalScore: 38.5},
+	}
+
+	want := "📰 **Wordle 300 summary**\n" +
+		"👥 3 played\n" +
+		"🏆 Best score: 3/6 by alice, bob\n" +
+		"✅ Solved: alice (3/6*), bob (3/6)\n" +
+		"💀 Failed: carol\n" +
+		"\n**Leaderboard**\n" +
+		"1. bob — 40.00\n" +
+		"2. alice — 38.50\n"
+	if got := dailySummary(300, attempts, entries, nil); got != want {
+		t.Errorf("dailySummary() = %q, want %q", got, want)
+	}
+
+	teams := []teamStanding{
+		{Name: "red", Score: 40, Players: 2},
+		{Name: "blue", Score: 12.25, Players: 1},
+	}
+	wantTeams := want + "\n**Teams**\n" +
+		"**1.** red · 40.0 points · 2 players\n" +
+		"**2.** blue · 12.2 points · 1 player\n"
+	if got := dailySummary(300, attempts, entries, teams); got != wantTeams {
+		t.Errorf("dailySummary() with teams = %q, want %q", got, wantTeams)
+	}
+
+	if got := dailySummary(300, nil, nil, nil); !strings.Contains(got, "Nobody played") {
+		t.Errorf("dailySummary() without attempts = %q, want it to say nobody played", got)
+	}
+}
+
+func TestDailySummaryBusyChannel(t *testing.T) {
+	var attempts []db.Attempt
+	var entries []db.LeaderboardEntry
+	for n := 0; n < 300; n++ {
+		name := fmt.Sprintf("a-rather-long-player-name-%03d", n)
+		attempts = append(attempts, db.Attempt{UserName: name, Attempts: 2 + n%5, MaxAttempts: 6, Success: n%7 != 0})
+		entries = append(entries, db.LeaderboardEntry{Username: name, TotalScore: float64(300 - n)})
+	}
+
+	got := dailySummary(300, attempts, entries, nil)
+	if length := utf8.RuneCountInString(got); length > maxMessageLength {
+		t.Errorf("dailySummary() is %d characters long, want at most %d", length, maxMessageLength)
+	}
+	if !strings.Contains(got, "more") || !strings.Contains(got, "**Leaderboard**") {
+		t.Errorf("dailySummary() = %q, want shortened player lists and the leaderboard", got)
+	}
+}
+
+func TestJoinNames(t *testing.T) {
+	tests := []struct {
+		names     []string
+		maxLength int
+		want      string
+	}{
+		{[]string{"alice", "bob"}, 100, "alice, bob"},
+		{[]string{"alice", "bob", "carol"}, 20, "alice …and 2 more"},
+		{[]string{"alice", "bob", "caroline-long"}, 23, "alice, bob …and 1 more"},
+		{[]string{"a-very-long-name", "bob"}, 5, "a-very-long-name …and 1 more"},
+	}
+
+	for _, test := range tests {
+		if got := joinNames(test.names, test.maxLength); got != test.want {
+			t.Errorf("joinNames(%q, %d) = %q, want %q", test.names, test.maxLength, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package wordlebot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/andrerfcsantos/wordle-discord-bot/wordle"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// Team scoring methods, for how the leaderboard scores of the members of a team add up to the score of the team.
+const (
+	// TeamScoringSum adds up the scores of all the members, favouring bigger teams.
+	TeamScoringSum = "sum"
+	// TeamScoringTop averages the scores of the best members of each team, up to the team-top setting.
+	TeamScoringTop = "top"
+	// TeamScoringWeighted averages the scores of the members weighted by the games each played, so members who play
+	// more weigh more.
+	TeamScoringWeighted = "weighted"
+)
+
+var teamScoringMethods = []string{TeamScoringSum, TeamScoringTop, TeamScoringWeighted}
+
+// maxTeams is the most teams a channel can have.
+const maxTeams = 25
+
+var minTeamTop = 1.0
+
+// teamStanding is the position of a team, with the members that have a score in the leaderboard.
+type teamStanding struct {
+	RoleId string
+	Name   string
+	Score  float64
+	// Players is the number of members with a score, and Played the games they played.
+	Players int
+	Played  int
+}
+
+// teamStandings ranks the teams of a channel by aggregating the leaderboard entries of their members with a team
+// scoring method. Roles has the role ids of each player, by user id. Members with the roles of several teams count
+// for each of them. Teams are ranked best first, ties keeping the order of the teams.
+func teamStandings(teams []db.ChannelTeam, entries []db.LeaderboardEntry, roles map[string][]string, method string,
+	top int) []teamStanding {
+	standings := make([]teamStanding, len(teams))
+	for n, team := range teams {
+		var members []db.LeaderboardEntry
+		for _, e := range entries {
+			if sliceHasString(roles[e.UserId], team.RoleId) {
+				members = append(members, e)
+			}
+		}
+
+		standings[n] = teamStanding{RoleId: team.RoleId, Name: team.RoleName, Players: len(members)}
+		for _, m := range members {
+			standings[n].Played += m.Played
+		}
+		standings[n].Score = teamScore(members, method, top)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Score > standings[j].Score
+	})
+
+	return standings
+}
+
+// teamScore aggregates the leaderboard entries of the members of a team with a team scoring method.
+func teamScore(members []db.LeaderboardEntry, method string, top int) float64 {
+	if len(members) == 0 {
+		return 0
+	}
+
+	var score float64
+	switch method {
+	case TeamScoringTop:
+		// Entries are already sorted from the best score to the worst.
+		if len(members) > top {
+			members = members[:top]
+		}
+		for _, m := range members {
+			score += m.TotalScore
+		}
+		return score / float64(len(members))
+	case TeamScoringWeighted:
+		var played int
+		for _, m := range members {
+			score += m.TotalScore * float64(m.Played)
+			played += m.Played
+		}
+		if played == 0 {
+			return 0
+		}
+		return score / float64(played)
+	default:
+		for _, m := range members {
+			score += m.TotalScore
+		}
+		return score
+	}
+}
+
+// teamScoringRule explains how a team scoring method adds up the scores of the members of a team.
+func teamScoringRule(method string, top int) string {
+	switch method {
+	case TeamScoringTop:
+		return fmt.Sprintf("Teams score the average of their best %d members", top)
+	case TeamScoringWeighted:
+		return "Teams score the average of their members, weighted by games played"
+	default:
+		return "Teams score the sum of their members"
+	}
+}
+
+// teamStandings ranks the teams of a channel of a guild for today, with the given team scoring method. Returns no
+// standings if the channel has no teams.
+func (b *WordleBot) teamStandings(channelId string, guildId string, today int, method string,
+	top int) ([]teamStanding, error) {
+	teams, err := b.repository.ChannelTeams(channelId)
+	if err != nil {
+		return nil, fmt.Errorf("getting teams: %w", err)
+	}
+	if len(teams) == 0 {
+		return nil, nil
+	}
+
+	names, err := b.roleNames(guildId)
+	if err != nil {
+		log.Errorf("getting role names, using the names saved with the teams: %v\n", err)
+	}
+	for n, team := range teams {
+		if name, ok := names[team.RoleId]; ok {
+			teams[n].RoleName = name
+		}
+	}
+
+	entries, _, err := b.leaderboard(channelId, "", today)
+	if err != nil {
+		return nil, fmt.Errorf("getting leaderboard: %w", err)
+	}
+
+	roles, err := b.memberRoles(guildId)
+	if err != nil {
+		return nil, fmt.Errorf("getting member roles: %w", err)
+	}
+
+	return teamStandings(teams, entries, roles, method, top), nil
+}
+
+// teamStandingsText lists team standings, one per line.
+func teamStandingsText(standings []teamStanding) string {
+	var builder strings.Builder
+	for n, t := range standings {
+		players := "1 player"
+		if t.Players != 1 {
+			players = fmt.Sprintf("%d players", t.Players)
+		}
+		fmt.Fprintf(&builder, "**%d.** %s · %.1f points · %s\n", n+1, t.Name, t.Score, players)
+	}
+	return builder.String()
+}
+
+func (b *WordleBot) HandleTeamsInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	if i.GuildID == "" {
+		return respondEphemeralEmbed(s, i, newEmbed("", "Teams are only available in servers.", colorWarning))
+	}
+
+	settings := b.channelSettings(i.ChannelID)
+	method, top := settings.TeamScoring, settings.TeamTop
+	options := subCommandOptions(i)
+	if opt, ok := options["method"]; ok {
+		method = opt.StringValue()
+	}
+	if opt, ok := options["top"]; ok {
+		top = int(opt.IntValue())
+	}
+
+	// Ranking teams lists the members of the guild, which can take longer than discord waits for an answer.
+	err := deferResponse(s, i, flags)
+	if err != nil {
+		return fmt.Errorf("deferring response: %w", err)
+	}
+
+	today := wordle.DayForDate(time.Now().UTC())
+	standings, err := b.teamStandings(i.ChannelID, i.GuildID, today, method, top)
+	if err != nil {
+		log.Errorf("Error handling teams interaction: %v", err)
+		editResponseEmbed(s, i, newEmbed("", problemMessage, colorFailure))
+		return err
+	}
+
+	if len(standings) == 0 {
+		return editResponseEmbed(s, i, newEmbed("", "This channel has no teams yet. Admins can add a team for "+
+			"a role with `/wordle team-roles add`.", colorNeutral))
+	}
+
+	embed := newEmbed("Teams", fmt.Sprintf("Team scores of the last %d days, up to Wordle %d.\n\n%s",
+		settings.LeaderboardDays, today, teamStandingsText(standings)), colorInfo)
+	embed.Footer = scoringFooter(teamScoringRule(method, top), settings)
+
+	return editResponseEmbed(s, i, embed)
+}
+
+func (b *WordleBot) HandleTeamRolesInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	if i.GuildID == "" {
+		return respondEphemeralEmbed(s, i, newEmbed("", "Teams are only available in servers.", colorWarning))
+	}
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	}
+
+	role := subCommandOptions(i)["role"].RoleValue(s, i.GuildID)
+	name := role.Name
+	if name == "" {
+		name = role.ID
+	}
+
+	switch subCommandGroupCommand(i) {
+	case "add":
+		teams, err := b.repository.ChannelTeams(i.ChannelID)
+		if err != nil {
+			return fmt.Errorf("getting teams: %w", err)
+		}
+		if len(teams) >= maxTeams && !hasTeam(teams, role.ID) {
+			return respondEphemeralEmbed(s, i, newEmbed("", fmt.Sprintf("A channel can have at most %d teams.",
+				maxTeams), colorWarning))
+		}
+
+		err = b.repository.SaveChannelTeam(db.ChannelTeam{ChannelId: i.ChannelID, RoleId: role.ID, RoleName: name})
+		if err != nil {
+			return fmt.Errorf("saving team: %w", err)
+		}
+
+		return respondEmbed(s, i, newEmbed("", fmt.Sprintf("Members with the %s role now compete as a team in "+
+			"this channel.", name), colorSuccess))
+	case "remove":
+		deleted, err := b.repository.DeleteChannelTeam(i.ChannelID, role.ID)
+		if err != nil {
+			return fmt.Errorf("deleting team: %w", err)
+		}
+		if !deleted {
+			return respondEphemeralEmbed(s, i, newEmbed("", fmt.Sprintf("The %s role isn't a team in this channel.",
+				name), colorNeutral))
+		}
+
+		return respondEmbed(s, i, newEmbed("", fmt.Sprintf("The %s role is no longer a team in this channel.", name),
+			colorSuccess))
+	}
+
+	return nil
+}
+
+func hasTeam(teams []db.ChannelTeam, roleId string) bool {
+	for _, t := range teams {
+		if t.RoleId == roleId {
+			return true
+		}
+	}
+	return false
+}
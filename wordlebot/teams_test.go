@@ -0,0 +1,66 @@
+package wordlebot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestTeamStandings(t *testing.T) {
+	teams := []db.ChannelTeam{
+		{RoleId: "g", RoleName: "green"},
+		{RoleId: "r", RoleName: "red"},
+		{RoleId: "b", RoleName: "blue"},
+	}
+	entries := []db.LeaderboardEntry{
+		{UserId: "1", TotalScore: 30, Played: 1},
+		{UserId: "2", TotalScore: 20, Played: 3},
+		{UserId: "3", TotalScore: 10, Played: 1},
+		{UserId: "4", TotalScore: 5, Played: 1},
+		{UserId: "5", TotalScore: 1, Played: 1},
+	}
+	// Player 3 is in two teams, and player 5 in none.
+	roles := map[string][]string{
+		"1": {"r"},
+		"2": {"b"},
+		"3": {"r", "b"},
+		"4": {"b", "other"},
+	}
+
+	tests := []struct {
+		method string
+		top    int
+		want   []teamStanding
+	}{
+		{TeamScoringSum, 3, []teamStanding{
+			{RoleId: "r", Name: "red", Score: 40, Players: 2, Played: 2},
+			{RoleId: "b", Name: "blue", Score: 35, Players: 3, Played: 5},
+			{RoleId: "g", Name: "green", Score: 0, Players: 0, Played: 0},
+		}},
+		{TeamScoringTop, 2, []teamStanding{
+			{RoleId: "r", Name: "red", Score: 20, Players: 2, Played: 2},
+			{RoleId: "b", Name: "blue", Score: 15, Players: 3, Played: 5},
+			{RoleId: "g", Name: "green", Score: 0, Players: 0, Played: 0},
+		}},
+		{TeamScoringWeighted, 3, []teamStanding{
+			{RoleId: "r", Name: "red", Score: 20, Players: 2, Played: 2},
+			{RoleId: "b", Name: "blue", Score: 15, Players: 3, Played: 5},
+			{RoleId: "g", Name: "green", Score: 0, Players: 0, Played: 0},
+		}},
+	}
+
+	for _, test := range tests {
+		standings := teamStandings(teams, entries, roles, test.method, test.top)
+		if len(standings) != len(test.want) {
+			t.Fatalf("teamStandings(%q, %d) = %+v, want %+v", test.method, test.top, standings, test.want)
+		}
+		for n, want := range test.want {
+			got := standings[n]
+			if got.RoleId != want.RoleId || got.Name != want.Name || got.Players != want.Players ||
+				got.Played != want.Played || math.Abs(got.Score-want.Score) > 1e-9 {
+				t.Errorf("teamStandings(%q, %d)[%d] = %+v, want %+v", test.method, test.top, n, got, want)
+			}
+		}
+	}
+}
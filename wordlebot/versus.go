@@ -0,0 +1,144 @@
+package wordlebot
+
+import (
+	"fmt"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+	"github.com/bwmarrin/discordgo"
+	log "github.com/sirupsen/logrus"
+)
+
+// versusStats compares two players, a and b, over the days both of them played.
+type versusStats struct {
+	Days  int
+	WinsA int
+	WinsB int
+	Draws int
+	// AvgDifference is the average of the guesses of a minus the guesses of b, so it's negative when a needs fewer
+	// guesses. Games lost count as failedAttemptsValue guesses.
+	AvgDifference float64
+	// LongestRunA and LongestRunB are the most consecutive days, of the days both played, each player won.
+	LongestRunA int
+	LongestRunB int
+	// HardModeA and HardModeB are the number of days each player played in hard mode.
+	HardModeA int
+	HardModeB int
+}
+
+// newVersusStats compares two players from their results on the days both of them played, in order of day. The
+// player that needs fewer guesses wins the day.
+func newVersusStats(days []db.HeadToHeadDay) versusStats {
+	var stats versusStats
+
+	var runA, runB int
+	var difference float64
+	for _, d := range days {
+		stats.Days++
+		if d.HardModeA {
+			stats.HardModeA++
+		}
+		if d.HardModeB {
+			stats.HardModeB++
+		}
+
+		a := attemptsValue(db.Attempt{Attempts: d.AttemptsA, Success: d.SuccessA})
+		b := attemptsValue(db.Attempt{Attempts: d.AttemptsB, Success: d.SuccessB})
+		difference += a - b
+
+		switch {
+		case a < b:
+			stats.WinsA++
+			runA, runB = runA+1, 0
+		case b < a:
+			stats.WinsB++
+			runA, runB = 0, runB+1
+		default:
+			stats.Draws++
+			runA, runB = 0, 0
+		}
+
+		if runA > stats.LongestRunA {
+			stats.LongestRunA = runA
+		}
+		if runB > stats.LongestRunB {
+			stats.LongestRunB = runB
+		}
+	}
+
+	if stats.Days > 0 {
+		stats.AvgDifference = difference / float64(stats.Days)
+	}
+
+	return stats
+}
+
+func (b *WordleBot) HandleVersusInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	flags := b.responseFlags(i)
+
+	options := subCommandOptions(i)
+
+	var users [2]*discordgo.User
+	for n, name := range []string{"player1", "player2"} {
+		user, err := b.playerOption(s, i.ChannelID, options[name])
+		if err != nil {
+			log.Errorf("Error handling versus interaction: %v", err)
+			respondProblem(s, i, flags)
+			return err
+		}
+		if user == nil {
+			return respondEphemeralEmbed(s, i, unknownPlayerEmbed(options[name]))
+		}
+		users[n] = user
+	}
+	userA, userB := users[0], users[1]
+
+	if userA.ID == userB.ID {
+		return respondEphemeralEmbed(s, i, newEmbed("", "Pick two different players to compare.", colorWarning))
+	}
+
+	days, err := b.repository.HeadToHead(i.ChannelID, userA.ID, userB.ID)
+	if err != nil {
+		log.Errorf("Error handling versus interaction: %v", err)
+		respondProblem(s, i, flags)
+		return err
+	}
+
+	if len(days) == 0 {
+		return respondEmbedWithFlags(s, i, newEmbed("", fmt.Sprintf("%s and %s never played the same wordle in "+
+			"this channel.", userA.Username, userB.Username), colorNeutral), flags)
+	}
+
+	embed := versusEmbed(userA.Username, userB.Username, newVersusStats(days))
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: fmt.Sprintf("Wordle %d to %d · Games lost count as %d guesses", days[0].Day, days[len(days)-1].Day,
+			failedAttemptsValue),
+	}
+
+	return respondEmbedWithFlags(s, i, embed, flags)
+}
+
+// versusEmbed shows the comparison of two players.
+func versusEmbed(nameA string, nameB string, stats versusStats) *discordgo.MessageEmbed {
+	embed := newEmbed(fmt.Sprintf("%s vs %s", nameA, nameB),
+		fmt.Sprintf("Compared over the %d days both played in this channel.", stats.Days), colorInfo)
+
+	difference := "Even"
+	switch {
+	case stats.AvgDifference < 0:
+		difference = fmt.Sprintf("%s by %.2f guesses", nameA, -stats.AvgDifference)
+	case stats.AvgDifference > 0:
+		difference = fmt.Sprintf("%s by %.2f guesses", nameB, stats.AvgDifference)
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		statField(fmt.Sprintf("%s wins", nameA), stats.WinsA),
+		statField(fmt.Sprintf("%s wins", nameB), stats.WinsB),
+		statField("Draws", stats.Draws),
+		statField("Avg. difference", difference),
+		statField("Longest winning run", fmt.Sprintf("%s: %d\n%s: %d", nameA, stats.LongestRunA, nameB,
+			stats.LongestRunB)),
+		statField("Hard mode days", fmt.Sprintf("%s: %d\n%s: %d", nameA, stats.HardModeA, nameB, stats.HardModeB)),
+	}
+
+	return embed
+}
@@ -0,0 +1,43 @@
+package wordlebot
+
+import (
+	"testing"
+
+	"github.com/andrerfcsantos/wordle-discord-bot/db"
+)
+
+func TestVersusStats(t *testing.T) {
+	days := []db.HeadToHeadDay{
+		{Day: 200, AttemptsA: 3, SuccessA: true, AttemptsB: 4, SuccessB: true, HardModeA: true},
+		{Day: 201, AttemptsA: 4, SuccessA: true, AttemptsB: 6, SuccessB: false, HardModeA: true},
+		{Day: 203, AttemptsA: 5, SuccessA: true, AttemptsB: 5, SuccessB: true},
+		{Day: 204, AttemptsA: 6, SuccessA: false, AttemptsB: 6, SuccessB: true, HardModeB: true},
+		{Day: 205, AttemptsA: 6, SuccessA: false, AttemptsB: 6, SuccessB: false},
+		{Day: 206, AttemptsA: 2, SuccessA: true, AttemptsB: 3, SuccessB: true},
+	}
+
+	got := newVersusStats(days)
+	want := versusStats{
+		Days:          6,
+		WinsA:         3,
+		WinsB:         1,
+		Draws:         2,
+		AvgDifference: (-1.0 - 3 + 0 + 1 + 0 - 1) / 6,
+		LongestRunA:   2,
+		LongestRunB:   1,
+		HardModeA:     2,
+		HardModeB:     1,
+	}
+
+	if got != want {
+		t.Fatalf("newVersusStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestVersusEmbedDifference(t *testing.T) {
+	embed := versusEmbed("alice", "bob", versusStats{Days: 2, AvgDifference: 0.5})
+
+	if got := embed.Fields[3].Value; got != "bob by 0.50 guesses" {
+		t.Fatalf("versusEmbed() difference = %q, want \"bob by 0.50 guesses\"", got)
+	}
+}
@@ -0,0 +1,77 @@
+package wordlebot
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// publicOption lets read-only commands choose whether everyone in the channel sees the response, or only the user.
+var publicOption = &discordgo.ApplicationCommandOption{
+	Name:        "public",
+	Type:        discordgo.ApplicationCommandOptionBoolean,
+	Description: "Show the response to everyone in the channel. Defaults to the setting of the channel.",
+}
+
+// adminPermissions are the permissions that allow members to change the settings reserved to admins.
+const adminPermissions = discordgo.PermissionAdministrator | discordgo.PermissionManageChannels
+
+// responseFlags returns the flags of the response of a read-only command: ephemeral unless the public option or,
+// without it, the setting of the channel say otherwise. Untracked channels answer publicly, the default.
+func (b *WordleBot) responseFlags(i *discordgo.InteractionCreate) uint64 {
+	if opt, ok := subCommandOptions(i)["public"]; ok {
+		return visibilityFlags(opt.BoolValue())
+	}
+
+	return visibilityFlags(b.channelSettings(i.ChannelID).PublicResponses)
+}
+
+func visibilityFlags(public bool) uint64 {
+	if public {
+		return 0
+	}
+	return uint64(discordgo.MessageFlagsEphemeral)
+}
+
+// isAdmin returns whether the user that triggered an interaction can manage the channel it was triggered in.
+func isAdmin(i *discordgo.InteractionCreate) bool {
+	return i.Member != nil && i.Member.Permissions&adminPermissions != 0
+}
+
+// respondNotChannelAdmin tells the user they can't change the settings of the channel, for commands only admins can
+// use.
+func respondNotChannelAdmin(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	return respondEphemeralEmbed(s, i, newEmbed("", "Only members who can manage this channel can change its "+
+		"settings.", colorFailure))
+}
+
+func (b *WordleBot) HandleVisibilityInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	public := subCommandOptions(i)["public"].BoolValue()
+
+	if !isAdmin(i) {
+		return respondNotChannelAdmin(s, i)
+	}
+
+	tracked, err := b.repository.IsTrackedChannel(i.ChannelID)
+	if err != nil {
+		return fmt.Errorf("checking tracked channel: %w", err)
+	}
+	if !tracked {
+		return respondEphemeralEmbed(s, i, notTrackedEmbed())
+	}
+
+	err = b.setChannelSettings(i.ChannelID, map[string]string{settingPublicResponses: strconv.FormatBool(public)})
+	if err != nil {
+		return fmt.Errorf("setting public responses: %w", err)
+	}
+
+	content := "Read-only commands will now only be shown to the member who uses them, unless they ask otherwise " +
+		"with `public`."
+	if public {
+		content = "Read-only commands will now be shown to everyone in this channel, unless they ask otherwise " +
+			"with `public`."
+	}
+
+	return respondEmbed(s, i, newEmbed("", content, colorSuccess))
+}
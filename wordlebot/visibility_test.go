@@ -0,0 +1,56 @@
+package wordlebot
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestResponseFlagsWithPublicOption(t *testing.T) {
+	interaction := func(public bool) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionApplicationCommand,
+			Data: discordgo.ApplicationCommandInteractionData{
+				Name: "wordle",
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{
+						Name: "leaderboard",
+						Type: discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandInteractionDataOption{
+							{Name: "public", Type: discordgo.ApplicationCommandOptionBoolean, Value: public},
+						},
+					},
+				},
+			},
+		}}
+	}
+
+	// The option is used without looking up the channel default
+	b := &WordleBot{}
+
+	if got := b.responseFlags(interaction(true)); got != 0 {
+		t.Errorf("responseFlags(public: true) = %d, want 0", got)
+	}
+	if got := b.responseFlags(interaction(false)); got != uint64(discordgo.MessageFlagsEphemeral) {
+		t.Errorf("responseFlags(public: false) = %d, want %d", got, discordgo.MessageFlagsEphemeral)
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	tests := []struct {
+		member *discordgo.Member
+		want   bool
+	}{
+		{nil, false},
+		{&discordgo.Member{Permissions: discordgo.PermissionSendMessages}, false},
+		{&discordgo.Member{Permissions: discordgo.PermissionSendMessages | discordgo.PermissionManageChannels}, true},
+		{&discordgo.Member{Permissions: discordgo.PermissionAdministrator}, true},
+	}
+
+	for _, test := range tests {
+		i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Member: test.member}}
+		if got := isAdmin(i); got != test.want {
+			t.Errorf("isAdmin(%+v) = %v, want %v", test.member, got, test.want)
+		}
+	}
+}